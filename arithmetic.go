@@ -8,6 +8,7 @@ const (
 	x = iota
 	y
 	z
+	w
 )
 
 func clone(a []float64) []float64 {
@@ -319,7 +320,7 @@ func rotate(a []float64, angle float64, axis []float64) []float64 {
 	x, _ := cross(u, a)
 	d := dot(u, a)
 
-	add(a, scale(a, cos))
+	scale(a, cos)
 	add(a, scale(x, sin))
 	add(a, scale(scale(u, d), 1-cos))
 
@@ -404,3 +405,95 @@ func swizzle(a []float64, indices ...int) ([]float64, error) {
 	}
 
 }
+
+func mul(a, b []float64) []float64 {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		a[i] *= b[i]
+	}
+	return a
+}
+
+func div(a, b []float64) []float64 {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		a[i] /= b[i]
+	}
+	return a
+}
+
+func minimum(a, b []float64) []float64 {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		a[i] = math.Min(a[i], b[i])
+	}
+	return a
+}
+
+func maximum(a, b []float64) []float64 {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		a[i] = math.Max(a[i], b[i])
+	}
+	return a
+}
+
+func clamp(a, min, max []float64) []float64 {
+	return minimum(maximum(a, min), max)
+}
+
+func pow(a []float64, p float64) []float64 {
+	for i := range a {
+		a[i] = math.Pow(a[i], p)
+	}
+	return a
+}
+
+func apply(a []float64, f func(float64) float64) []float64 {
+	for i := range a {
+		a[i] = f(a[i])
+	}
+	return a
+}
+
+func zip(a, b []float64, f func(a, b float64) float64) []float64 {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		a[i] = f(a[i], b[i])
+	}
+	return a
+}
+
+func reduce(a []float64, f func(accumulator, v float64) float64, initial float64) float64 {
+	for _, v := range a {
+		initial = f(initial, v)
+	}
+	return initial
+}
+
+// set the component at index i, growing the slice with zeros if it does not
+// exist yet
+func set(a []float64, i int, v float64) []float64 {
+	if i >= len(a) {
+		a = grow(a, i+1)
+	}
+
+	a[i] = v
+	return a
+}
+
+// grow returns the slice with the length n, truncating it or growing it with
+// zeros, and reuses the memory of the slice when its capacity allows it
+func grow(a []float64, n int) []float64 {
+	if n <= len(a) {
+		return a[:n]
+	}
+
+	if n <= cap(a) {
+		l := len(a)
+		a = a[:n]
+		for i := l; i < n; i++ {
+			a[i] = 0
+		}
+		return a
+	}
+
+	result := make([]float64, n)
+	copy(result, a)
+	return result
+}
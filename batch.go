@@ -0,0 +1,180 @@
+package vector
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// BatchOptions configures how the batch operations split their work across a
+// pool of goroutines. Every vector of a batch is computed on its own, so the
+// results are the same regardless of the number of workers.
+type BatchOptions struct {
+	// Workers is the number of goroutines, defaults to GOMAXPROCS
+	Workers int
+	// MinChunk is the smallest number of vectors a worker takes at a time, so
+	// batches smaller than it run on the calling goroutine, defaults to 4096
+	MinChunk int
+}
+
+func (opts BatchOptions) withDefaults() BatchOptions {
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+
+	if opts.MinChunk <= 0 {
+		opts.MinChunk = 4096
+	}
+
+	return opts
+}
+
+// BatchAdd adds b to every vector of the batch in place, like calling Add on
+// the MutableVector of each. It returns the error of the context if it is
+// cancelled before the batch is done, in which case only some of the vectors
+// have been changed.
+func BatchAdd(ctx context.Context, data []Vector, b Vector, opts BatchOptions) error {
+	return parallel(ctx, len(data), opts, func(from, to int) {
+		for _, v := range data[from:to] {
+			add(v, b)
+		}
+	})
+}
+
+// BatchScale multiplies every vector of the batch in place by size, and
+// returns the error of the context like BatchAdd
+func BatchScale(ctx context.Context, data []Vector, size float64, opts BatchOptions) error {
+	return parallel(ctx, len(data), opts, func(from, to int) {
+		for _, v := range data[from:to] {
+			scale(v, size)
+		}
+	})
+}
+
+// BatchUnit normalizes every vector of the batch in place to the length of
+// one, and returns the error of the context like BatchAdd
+func BatchUnit(ctx context.Context, data []Vector, opts BatchOptions) error {
+	return parallel(ctx, len(data), opts, func(from, to int) {
+		for _, v := range data[from:to] {
+			unit(v)
+		}
+	})
+}
+
+// BatchDistance returns the distance from every vector of the batch to the
+// query, where the metric defaults to Euclidean. It returns the error of the
+// context if it is cancelled before the batch is done.
+func BatchDistance(ctx context.Context, data []Vector, query Vector, metric Metric, opts BatchOptions) (Vector, error) {
+	if metric == nil {
+		metric = Euclidean
+	}
+
+	result := make(Vector, len(data))
+	err := parallel(ctx, len(data), opts, func(from, to int) {
+		for i := from; i < to; i++ {
+			result[i] = metric(data[i], query)
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BatchTransform returns every vector of the batch multiplied by the matrix m,
+// given as a list of rows, so the results have one dimension per row. Missing
+// components count as zero. The results share a single allocation. It returns
+// the error of the context if it is cancelled before the batch is done.
+func BatchTransform(ctx context.Context, data []Vector, m []Vector, opts BatchOptions) ([]Vector, error) {
+	rows := len(m)
+	result, backing := make([]Vector, len(data)), make(Vector, len(data)*rows)
+
+	err := parallel(ctx, len(data), opts, func(from, to int) {
+		for i := from; i < to; i++ {
+			v := backing[i*rows : (i+1)*rows : (i+1)*rows]
+			for r, row := range m {
+				var sum float64
+				for j := 0; j < len(row) && j < len(data[i]); j++ {
+					sum += row[j] * data[i][j]
+				}
+				v[r] = sum
+			}
+			result[i] = v
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parallel runs work over the range [0, n) split into chunks of at least
+// MinChunk, which a pool of workers take one at a time until the range is
+// done or the context is cancelled
+func parallel(ctx context.Context, n int, opts BatchOptions, work func(from, to int)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	opts = opts.withDefaults()
+
+	// a few chunks per worker balances the load without making the chunks so
+	// small that taking them costs more than computing them
+	chunk := n / (4 * opts.Workers)
+	if chunk < opts.MinChunk {
+		chunk = opts.MinChunk
+	}
+
+	chunks := (n + chunk - 1) / chunk
+	workers := opts.Workers
+	if workers > chunks {
+		workers = chunks
+	}
+
+	var next int64
+	var cancelled int32
+
+	worker := func() {
+		for {
+			c := int(atomic.AddInt64(&next, 1) - 1)
+			if c >= chunks {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				atomic.StoreInt32(&cancelled, 1)
+				return
+			default:
+			}
+
+			from, to := c*chunk, (c+1)*chunk
+			if to > n {
+				to = n
+			}
+			work(from, to)
+		}
+	}
+
+	if workers <= 1 {
+		worker()
+	} else {
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer wg.Done()
+				worker()
+			}()
+		}
+		wg.Wait()
+	}
+
+	if atomic.LoadInt32(&cancelled) == 1 {
+		return ctx.Err()
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package vector_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func batch(r *rand.Rand, n, dim int) []vec {
+	result := make([]vec, n)
+	for i := range result {
+		result[i] = randomSignal(r, dim)
+	}
+	return result
+}
+
+func cloneBatch(data []vec) []vec {
+	result := make([]vec, len(data))
+	for i := range data {
+		result[i] = data[i].Clone()
+	}
+	return result
+}
+
+func sameBatch(a, b []vec) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if len(a[i]) != len(b[i]) {
+			return false
+		}
+		for j := range a[i] {
+			if a[i][j] != b[i][j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestBatch(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ctx := context.Background()
+	data, b, query := batch(r, 1000, 5), randomSignal(r, 5), randomSignal(r, 5)
+	m := []vec{{1, 2, 3, 4, 5}, {0, -1}, {0.5, 0.5, 0.5, 0.5, 0.5}}
+
+	added, scaled, units := cloneBatch(data), cloneBatch(data), cloneBatch(data)
+	distances, transformed := make(vec, len(data)), make([]vec, len(data))
+	for i := range data {
+		vector.In(added[i]).Add(b)
+		vector.In(scaled[i]).Scale(-1.5)
+		vector.In(units[i]).Unit()
+		distances[i] = vector.Manhattan(data[i], query)
+		transformed[i] = vec{data[i].Dot(m[0]), -data[i][1], data[i].Dot(m[2])}
+	}
+
+	// the results must be the same for any number of workers and chunk size
+	for _, opts := range []vector.BatchOptions{
+		{},
+		{Workers: 1},
+		{Workers: 3, MinChunk: 1},
+		{Workers: 8, MinChunk: 7},
+		{Workers: 64, MinChunk: 100},
+	} {
+		result := cloneBatch(data)
+		if err := vector.BatchAdd(ctx, result, b, opts); err != nil || !sameBatch(result, added) {
+			t.Errorf("%+v: unexpected addition (%v)", opts, err)
+		}
+
+		result = cloneBatch(data)
+		if err := vector.BatchScale(ctx, result, -1.5, opts); err != nil || !sameBatch(result, scaled) {
+			t.Errorf("%+v: unexpected scaling (%v)", opts, err)
+		}
+
+		result = cloneBatch(data)
+		if err := vector.BatchUnit(ctx, result, opts); err != nil || !sameBatch(result, units) {
+			t.Errorf("%+v: unexpected normalization (%v)", opts, err)
+		}
+
+		if d, err := vector.BatchDistance(ctx, data, query, vector.Manhattan, opts); err != nil || !sameBatch([]vec{d}, []vec{distances}) {
+			t.Errorf("%+v: unexpected distances (%v)", opts, err)
+		}
+
+		if result, err := vector.BatchTransform(ctx, data, m, opts); err != nil || len(result) != len(transformed) {
+			t.Errorf("%+v: unexpected transform (%v)", opts, err)
+		} else {
+			for i := range result {
+				if !result[i].Equal(transformed[i]) {
+					t.Fatalf("%+v: expected %v, got %v", opts, transformed[i], result[i])
+				}
+			}
+		}
+	}
+
+	if d, err := vector.BatchDistance(ctx, data[:1], query, nil, vector.BatchOptions{}); err != nil || d[0] != vector.Euclidean(data[0], query) {
+		t.Errorf("expected the metric to default to Euclidean, got %v (%v)", d, err)
+	}
+}
+
+func TestBatchCancellation(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	data := batch(r, 1000, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	original := cloneBatch(data)
+	if err := vector.BatchAdd(ctx, data, vec{1, 1, 1}, vector.BatchOptions{}); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if !sameBatch(data, original) {
+		t.Error("expected a cancelled batch to leave the vectors untouched")
+	}
+
+	// cancelling while the batch runs stops it after the current chunk
+	ctx, cancel = context.WithCancel(context.Background())
+	calls := 0
+	metric := func(a, b vec) float64 {
+		if calls++; calls == 50 {
+			cancel()
+		}
+		return 0
+	}
+
+	opts := vector.BatchOptions{Workers: 1, MinChunk: 300}
+	if _, err := vector.BatchDistance(ctx, data, vec{}, metric, opts); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	if calls != 300 {
+		t.Errorf("expected the batch to stop after the first chunk, got %v calls", calls)
+	}
+}
+
+func benchmarkBatch(b *testing.B, opts vector.BatchOptions) {
+	r := rand.New(rand.NewSource(1))
+	data, v := batch(r, 1<<20, 4), randomSignal(r, 4)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vector.BatchAdd(context.Background(), data, v, opts)
+	}
+}
+
+func BenchmarkBatchAddSerial(b *testing.B) {
+	benchmarkBatch(b, vector.BatchOptions{Workers: 1})
+}
+
+func BenchmarkBatchAddParallel(b *testing.B) {
+	benchmarkBatch(b, vector.BatchOptions{})
+}
+
+func BenchmarkAddLoop(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	data, v := batch(r, 1<<20, 4), randomSignal(r, 4)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, d := range data {
+			vector.In(d).Add(v)
+		}
+	}
+}
+
+func BenchmarkBatchDistanceParallel(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	data, query := batch(r, 1<<20, 16), randomSignal(r, 16)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vector.BatchDistance(context.Background(), data, query, nil, vector.BatchOptions{})
+	}
+}
@@ -0,0 +1,165 @@
+package vector
+
+import (
+	"math/cmplx"
+	"unsafe"
+)
+
+// ComplexVector is a vector of complex numbers, where all operations return a
+// new vector like Vector
+type ComplexVector []complex128
+
+// Complex returns the complex vector with the given real and imaginary parts,
+// with the dimension of the real part
+func Complex(re, im Vector) ComplexVector {
+	result := make(ComplexVector, len(re))
+	for i := range re {
+		result[i] = complex(re[i], component(im, i))
+	}
+	return result
+}
+
+// FromInterleaved returns the complex vector of a buffer holding the real and
+// imaginary part of every component after each other. A last real part without
+// an imaginary part gets a zero imaginary part.
+func FromInterleaved(v Vector) ComplexVector {
+	result := make(ComplexVector, (len(v)+1)/2)
+	copy(interleaved(result), v)
+	return result
+}
+
+// Interleaved returns the real and imaginary part of every component after
+// each other, which is the layout most signal processing libraries expect
+func (a ComplexVector) Interleaved() Vector {
+	return clone(interleaved(a))
+}
+
+// Real returns the real parts of the complex vector
+func (a ComplexVector) Real() Vector {
+	result := make(Vector, len(a))
+	for i := range a {
+		result[i] = real(a[i])
+	}
+	return result
+}
+
+// Imag returns the imaginary parts of the complex vector
+func (a ComplexVector) Imag() Vector {
+	result := make(Vector, len(a))
+	for i := range a {
+		result[i] = imag(a[i])
+	}
+	return result
+}
+
+// Clone a complex vector
+func (a ComplexVector) Clone() ComplexVector {
+	result := make(ComplexVector, len(a))
+	copy(result, a)
+	return result
+}
+
+// Add returns the sum of two complex vectors
+func (a ComplexVector) Add(b ComplexVector) ComplexVector {
+	result := a.Clone()
+	n := 2 * len(b)
+	if n > 2*len(a) {
+		n = 2 * len(a)
+	}
+
+	r := interleaved(result)[:n]
+	axpyUnitaryTo(r, 1, interleaved(b)[:n], r)
+	return result
+}
+
+// Sub returns the difference of two complex vectors
+func (a ComplexVector) Sub(b ComplexVector) ComplexVector {
+	result := a.Clone()
+	n := 2 * len(b)
+	if n > 2*len(a) {
+		n = 2 * len(a)
+	}
+
+	r := interleaved(result)[:n]
+	axpyUnitaryTo(r, -1, interleaved(b)[:n], r)
+	return result
+}
+
+// Scale returns the complex vector multiplied by a complex number
+func (a ComplexVector) Scale(size complex128) ComplexVector {
+	result := a.Clone()
+
+	if imag(size) == 0 {
+		r := interleaved(result)
+		scalUnitaryTo(r, real(size), r)
+		return result
+	}
+
+	for i := range result {
+		result[i] *= size
+	}
+	return result
+}
+
+// Mul returns the elementwise product of two complex vectors
+func (a ComplexVector) Mul(b ComplexVector) ComplexVector {
+	result := make(ComplexVector, len(a))
+	for i := 0; i < len(a) && i < len(b); i++ {
+		result[i] = a[i] * b[i]
+	}
+	return result
+}
+
+// Conjugate returns the complex vector with every imaginary part negated
+func (a ComplexVector) Conjugate() ComplexVector {
+	result := make(ComplexVector, len(a))
+	for i := range a {
+		result[i] = cmplx.Conj(a[i])
+	}
+	return result
+}
+
+// Dot returns the Hermitian inner product of two complex vectors, where the
+// components of the first vector are conjugated, so a.Dot(a) is the squared
+// magnitude of a
+func (a ComplexVector) Dot(b ComplexVector) complex128 {
+	var result complex128
+	for i := 0; i < len(a) && i < len(b); i++ {
+		result += cmplx.Conj(a[i]) * b[i]
+	}
+	return result
+}
+
+// Magnitude of a complex vector, which is the magnitude of its interleaved
+// real and imaginary parts
+func (a ComplexVector) Magnitude() float64 {
+	return magnitude(interleaved(a))
+}
+
+// Unit returns a direction complex vector with the length of one
+func (a ComplexVector) Unit() ComplexVector {
+	l := a.Magnitude()
+	if l < 1e-8 {
+		return a.Clone()
+	}
+	return a.Scale(complex(1/l, 0))
+}
+
+// Equal compares that two complex vectors are equal to each other, with the
+// same tolerance as Vector
+func (a ComplexVector) Equal(b ComplexVector) bool {
+	return len(a) == len(b) && equal(interleaved(a), interleaved(b))
+}
+
+// interleaved returns the memory of a complex vector as the float64 slice of
+// its real and imaginary parts, without copying. A complex128 is stored as its
+// real part followed by its imaginary part, so operations that are the same on
+// both parts can run the float64 kernels on it, which needs unsafe since Go has
+// no conversion between the two slice types.
+func interleaved(a ComplexVector) []float64 {
+	if len(a) == 0 {
+		return nil
+	}
+
+	return (*[1 << 30]float64)(unsafe.Pointer(&a[0]))[: 2*len(a) : 2*cap(a)]
+}
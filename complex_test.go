@@ -0,0 +1,120 @@
+package vector_test
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func randomComplex(r *rand.Rand, n int) vector.ComplexVector {
+	result := make(vector.ComplexVector, n)
+	for i := range result {
+		result[i] = complex(r.NormFloat64(), r.NormFloat64())
+	}
+	return result
+}
+
+func TestComplexVectorArithmetic(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{1, 2, 3, 7, 16, 33} {
+		a, b := randomComplex(r, n), randomComplex(r, n)
+		s := complex(r.NormFloat64(), r.NormFloat64())
+
+		sum, difference, scaled, product := a.Add(b), a.Sub(b), a.Scale(s), a.Mul(b)
+		for i := range a {
+			if cmplx.Abs(sum[i]-(a[i]+b[i])) > 1e-12 || cmplx.Abs(difference[i]-(a[i]-b[i])) > 1e-12 {
+				t.Fatalf("unexpected sum or difference in component %v", i)
+			}
+
+			if cmplx.Abs(scaled[i]-a[i]*s) > 1e-12 || cmplx.Abs(product[i]-a[i]*b[i]) > 1e-12 {
+				t.Fatalf("unexpected scaling or product in component %v", i)
+			}
+		}
+
+		if twice := a.Scale(2); !twice.Equal(a.Add(a)) {
+			t.Error("expected scaling by a real number to match adding the vector to itself")
+		}
+
+		// the Hermitian inner product is conjugate symmetric, and the inner
+		// product of a vector with itself is its squared magnitude
+		if cmplx.Abs(a.Dot(b)-cmplx.Conj(b.Dot(a))) > 1e-12 {
+			t.Errorf("expected conjugate symmetry, got %v and %v", a.Dot(b), b.Dot(a))
+		}
+
+		if d := a.Dot(a); math.Abs(real(d)-a.Magnitude()*a.Magnitude()) > 1e-9 || imag(d) != 0 {
+			t.Errorf("expected a.Dot(a) to be the squared magnitude, got %v", d)
+		}
+
+		if cmplx.Abs(a.Dot(b.Scale(s))-s*a.Dot(b)) > 1e-9 || cmplx.Abs(a.Scale(s).Dot(b)-cmplx.Conj(s)*a.Dot(b)) > 1e-9 {
+			t.Error("expected the inner product to be linear in the second and conjugate linear in the first vector")
+		}
+
+		if math.Abs(a.Unit().Magnitude()-1) > 1e-12 {
+			t.Errorf("expected a unit vector, got magnitude %v", a.Unit().Magnitude())
+		}
+
+		if !a.Conjugate().Conjugate().Equal(a) || !a.Conjugate().Imag().Equal(a.Imag().Invert()) {
+			t.Error("unexpected conjugate")
+		}
+	}
+}
+
+func TestComplexVectorDimensions(t *testing.T) {
+	a := vector.ComplexVector{1 + 1i, 2, 3i}
+	b := vector.ComplexVector{1i}
+
+	if !a.Add(b).Equal(vector.ComplexVector{1 + 2i, 2, 3i}) || !a.Sub(b).Equal(vector.ComplexVector{1, 2, 3i}) {
+		t.Error("expected the missing components to count as zero")
+	}
+
+	if !b.Add(a).Equal(vector.ComplexVector{1 + 2i}) || !b.Mul(a).Equal(vector.ComplexVector{-1 + 1i}) {
+		t.Error("expected the first vector to decide the dimension")
+	}
+
+	original := a.Clone()
+	a.Add(b)
+	a.Scale(2i)
+	if !a.Equal(original) {
+		t.Error("expected the operations to leave the vector untouched")
+	}
+}
+
+func TestComplexVectorConversion(t *testing.T) {
+	a := vector.ComplexVector{1 + 2i, -3 + 4i}
+
+	if !a.Interleaved().Equal(vec{1, 2, -3, 4}) {
+		t.Errorf("unexpected interleaved buffer %v", a.Interleaved())
+	}
+
+	if !vector.FromInterleaved(vec{1, 2, -3, 4}).Equal(a) {
+		t.Error("expected the interleaved buffer to round trip")
+	}
+
+	if !vector.FromInterleaved(vec{1, 2, 5}).Equal(vector.ComplexVector{1 + 2i, 5}) {
+		t.Error("expected a missing imaginary part to be zero")
+	}
+
+	if !vector.Complex(a.Real(), a.Imag()).Equal(a) || !vector.Complex(vec{1, 2}, vec{3}).Equal(vector.ComplexVector{1 + 3i, 2}) {
+		t.Error("expected the real and imaginary parts to round trip")
+	}
+
+	buffer := a.Interleaved()
+	buffer[0] = 100
+	if real(a[0]) != 1 {
+		t.Error("expected the interleaved buffer to be a copy")
+	}
+}
+
+func BenchmarkComplexVectorAdd(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	x, y := randomComplex(r, 1024), randomComplex(r, 1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Add(y)
+	}
+}
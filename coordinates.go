@@ -0,0 +1,96 @@
+package vector
+
+import "math"
+
+func toPolar(a []float64) []float64 {
+	ax, ay := component(a, x), component(a, y)
+	return []float64{math.Hypot(ax, ay), math.Atan2(ay, ax)}
+}
+
+func fromPolar(a []float64) []float64 {
+	r, theta := component(a, 0), component(a, 1)
+	return []float64{r * math.Cos(theta), r * math.Sin(theta)}
+}
+
+func toCylindrical(a []float64) []float64 {
+	ax, ay := component(a, x), component(a, y)
+	return []float64{math.Hypot(ax, ay), math.Atan2(ay, ax), component(a, z)}
+}
+
+func fromCylindrical(a []float64) []float64 {
+	rho, phi := component(a, 0), component(a, 1)
+	return []float64{rho * math.Cos(phi), rho * math.Sin(phi), component(a, 2)}
+}
+
+func toSpherical(a []float64) []float64 {
+	ax, ay, az := component(a, x), component(a, y), component(a, z)
+	return []float64{
+		math.Sqrt(ax*ax + ay*ay + az*az),
+		math.Atan2(math.Hypot(ax, ay), az),
+		math.Atan2(ay, ax),
+	}
+}
+
+func fromSpherical(a []float64) []float64 {
+	r, theta, phi := component(a, 0), component(a, 1), component(a, 2)
+	sin := math.Sin(theta)
+	return []float64{
+		r * sin * math.Cos(phi),
+		r * sin * math.Sin(phi),
+		r * math.Cos(theta),
+	}
+}
+
+func toHyperspherical(a []float64) []float64 {
+	dim := len(a)
+	result := make([]float64, dim)
+
+	if dim == 0 {
+		return result
+	}
+
+	// a single component has no angle to hold its sign, so the signed value is
+	// kept as the radius to make the round trip exact
+	if dim == 1 {
+		result[0] = a[0]
+		return result
+	}
+
+	// tail holds the length of the components from index i and onwards
+	tail := make([]float64, dim+1)
+	for i := dim - 1; i >= 0; i-- {
+		tail[i] = math.Hypot(tail[i+1], a[i])
+	}
+
+	result[0] = tail[0]
+	for i := 0; i < dim-2; i++ {
+		result[i+1] = math.Atan2(tail[i+1], a[i])
+	}
+	result[dim-1] = math.Atan2(a[dim-1], a[dim-2])
+
+	return result
+}
+
+func fromHyperspherical(a []float64) []float64 {
+	dim := len(a)
+	result := make([]float64, dim)
+
+	if dim == 0 {
+		return result
+	}
+
+	if dim == 1 {
+		result[0] = a[0]
+		return result
+	}
+
+	// product holds r times the product of the sines of the angles seen so far
+	product := a[0]
+	for i := 1; i < dim; i++ {
+		result[i-1] = product * math.Cos(a[i])
+		product *= math.Sin(a[i])
+	}
+	result[dim-1] = product
+
+	return result
+}
@@ -0,0 +1,95 @@
+package vector_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPolarCoordinates(t *testing.T) {
+	if !(vec{0, 2}.ToPolar()).Equal(vec{2, math.Pi / 2}) {
+		t.Errorf("unexpected polar coordinates %v", vec{0, 2}.ToPolar())
+	}
+
+	if !(vec{-1, 0}.ToPolar()).Equal(vec{1, math.Pi}) {
+		t.Errorf("unexpected polar coordinates %v", vec{-1, 0}.ToPolar())
+	}
+
+	if (vec{1, 1}.ToPolar()).Y() != (vec{1, 1}).Angle() {
+		t.Error("polar angle differs from Angle")
+	}
+}
+
+func TestSphericalCoordinates(t *testing.T) {
+	if !(vec{0, 0, 3}.ToSpherical()).Equal(vec{3, 0, 0}) {
+		t.Errorf("unexpected spherical coordinates %v", vec{0, 0, 3}.ToSpherical())
+	}
+
+	if !(vec{0, 2, 0}.ToSpherical()).Equal(vec{2, math.Pi / 2, math.Pi / 2}) {
+		t.Errorf("unexpected spherical coordinates %v", vec{0, 2, 0}.ToSpherical())
+	}
+
+	if !(vec{1, 0, 1}.ToCylindrical()).Equal(vec{1, 0, 1}) {
+		t.Errorf("unexpected cylindrical coordinates %v", vec{1, 0, 1}.ToCylindrical())
+	}
+}
+
+func TestSphericalMathConvention(t *testing.T) {
+	cases := []struct {
+		v, math vec
+	}{
+		{vec{0, 1, 1}, vec{math.Sqrt2, math.Pi / 2, math.Pi / 4}},
+		{vec{1, 0, 0}, vec{1, 0, math.Pi / 2}},
+		{vec{0, 0, -2}, vec{2, 0, math.Pi}},
+		{vec{-1, -1, math.Sqrt2}, vec{2, -3 * math.Pi / 4, math.Pi / 4}},
+	}
+
+	for _, c := range cases {
+		// {r, θ, φ} in the math convention, with θ the azimuthal angle
+		converted, _ := c.v.ToSpherical().Swizzle(0, 2, 1)
+		if !converted.Equal(c.math) {
+			t.Errorf("expected %v in the math convention to be %v, got %v", c.v, c.math, converted)
+		}
+
+		physics, _ := c.math.Swizzle(0, 2, 1)
+		if !physics.FromSpherical().Equal(c.v) {
+			t.Errorf("expected %v in the math convention to convert back to %v, got %v", c.math, c.v, physics.FromSpherical())
+		}
+	}
+}
+
+func TestCoordinateRoundTrips(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+
+	for i := 0; i < 100; i++ {
+		v2 := vec{r.NormFloat64(), r.NormFloat64()}
+		v3 := vec{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}
+		v6 := vec{r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}
+
+		if !v2.ToPolar().FromPolar().Equal(v2) {
+			t.Errorf("polar round trip failed for %v", v2)
+		}
+
+		if !v3.ToCylindrical().FromCylindrical().Equal(v3) {
+			t.Errorf("cylindrical round trip failed for %v", v3)
+		}
+
+		if !v3.ToSpherical().FromSpherical().Equal(v3) {
+			t.Errorf("spherical round trip failed for %v", v3)
+		}
+
+		for _, v := range []vec{v2, v3, v6} {
+			if !v.ToHyperspherical().FromHyperspherical().Equal(v) {
+				t.Errorf("hyperspherical round trip failed for %v", v)
+			}
+		}
+
+		if v1 := (vec{-r.ExpFloat64()}); !v1.ToHyperspherical().FromHyperspherical().Equal(v1) {
+			t.Errorf("hyperspherical round trip failed for %v", v1)
+		}
+
+		if !v2.ToHyperspherical().Equal(v2.ToPolar()) {
+			t.Errorf("hyperspherical coordinates of %v differ from polar", v2)
+		}
+	}
+}
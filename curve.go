@@ -0,0 +1,709 @@
+package vector
+
+import (
+	"math"
+	"sort"
+)
+
+// CatmullRomAlpha is the exponent of the distance between the points in the
+// knot sequence of a Catmull-Rom spline, which decides its parameterization
+type CatmullRomAlpha float64
+
+const (
+	// CatmullRomUniform is the parameterization where every segment has the
+	// same length in the knot sequence
+	CatmullRomUniform CatmullRomAlpha = 0
+	// CatmullRomCentripetal is the parameterization which never forms cusps or
+	// self intersections within a segment
+	CatmullRomCentripetal CatmullRomAlpha = 0.5
+	// CatmullRomChordal is the parameterization where the knot sequence follows
+	// the distance between the points
+	CatmullRomChordal CatmullRomAlpha = 1
+)
+
+// Curve is a parametric curve defined for t between 0 and 1, in any number of
+// dimensions
+type Curve interface {
+	// At returns the point on the curve at t
+	At(t float64) Vector
+	// Derivative returns the derivative of the curve with respect to t
+	Derivative(t float64) Vector
+	// Bounds returns the componentwise minimum and maximum of the curve
+	Bounds() (min, max Vector)
+	// Flatten returns a polyline that stays within the tolerance of the curve
+	Flatten(tolerance float64) []Vector
+}
+
+// Bezier is a Bézier curve of arbitrary degree given by its control points,
+// where the degree is one less than the number of control points.
+type Bezier []Vector
+
+// At returns the point on the curve at t using de Casteljau's algorithm
+func (b Bezier) At(t float64) Vector {
+	if len(b) == 0 {
+		return nil
+	}
+
+	points := cloneMatrix(b)
+	for n := len(points) - 1; n > 0; n-- {
+		for i := 0; i < n; i++ {
+			lerp(points[i], points[i+1], t)
+		}
+	}
+
+	return points[0]
+}
+
+// Derivative returns the derivative of the curve at t, which is evaluated as
+// a Bézier curve of one degree lower
+func (b Bezier) Derivative(t float64) Vector {
+	if len(b) < 2 {
+		return make(Vector, len(b.point(0)))
+	}
+
+	return b.hodograph().At(t)
+}
+
+// Split divides the curve at t into two curves of the same degree, where the
+// first covers the curve from 0 to t and the second from t to 1
+func (b Bezier) Split(t float64) (Bezier, Bezier) {
+	n := len(b)
+	left, right := make(Bezier, n), make(Bezier, n)
+	points := cloneMatrix(b)
+
+	for i := 0; i < n; i++ {
+		left[i], right[n-1-i] = clone(points[0]), clone(points[n-1-i])
+		for j := 0; j < n-1-i; j++ {
+			lerp(points[j], points[j+1], t)
+		}
+	}
+
+	return left, right
+}
+
+// Bounds returns the exact componentwise minimum and maximum of the curve,
+// found from the end points and the roots of the derivative
+func (b Bezier) Bounds() (min, max Vector) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	min, max = clone(b[0]), clone(b[0])
+	extend(min, max, b[len(b)-1])
+
+	if len(b) < 3 {
+		return min, max
+	}
+
+	d := b.hodograph()
+	coefficients := make([]float64, len(d))
+
+	for j := range min {
+		for i := range d {
+			coefficients[i] = component(d[i], j)
+		}
+
+		var roots []float64
+		bernsteinRoots(coefficients, 0, 1, 0, &roots)
+
+		for _, t := range roots {
+			extend(min, max, b.At(t))
+		}
+	}
+
+	return min, max
+}
+
+// Flatten returns a polyline that stays within the tolerance of the curve, by
+// subdividing the curve until the control points are within the tolerance of
+// the line between the end points
+func (b Bezier) Flatten(tolerance float64) []Vector {
+	if len(b) == 0 {
+		return []Vector{}
+	}
+
+	result := []Vector{clone(b[0])}
+	b.flatten(tolerance, 0, &result)
+	return result
+}
+
+func (b Bezier) flatten(tolerance float64, depth int, result *[]Vector) {
+	last := b[len(b)-1]
+	flat := true
+
+	for _, p := range b[1 : len(b)-1] {
+		if segmentDistance(p, b[0], last) > tolerance {
+			flat = false
+			break
+		}
+	}
+
+	if flat || depth >= 32 {
+		*result = append(*result, clone(last))
+		return
+	}
+
+	left, right := b.Split(0.5)
+	left.flatten(tolerance, depth+1, result)
+	right.flatten(tolerance, depth+1, result)
+}
+
+// hodograph returns the derivative of the curve as a Bézier curve
+func (b Bezier) hodograph() Bezier {
+	n := len(b) - 1
+	d := make(Bezier, n)
+	for i := range d {
+		d[i] = scale(sub(resize(b[i+1], len(b[0])), b[i]), float64(n))
+	}
+	return d
+}
+
+func (b Bezier) point(i int) Vector {
+	if i < len(b) {
+		return b[i]
+	}
+	return nil
+}
+
+// Hermite is a cubic Hermite curve given by its two end points and the
+// derivatives of the curve at them
+type Hermite struct {
+	P0, T0 Vector
+	P1, T1 Vector
+}
+
+// At returns the point on the curve at t
+func (h Hermite) At(t float64) Vector {
+	return h.Bezier().At(t)
+}
+
+// Derivative returns the derivative of the curve at t
+func (h Hermite) Derivative(t float64) Vector {
+	return h.Bezier().Derivative(t)
+}
+
+// Split divides the curve at t into two Hermite curves, where the first
+// covers the curve from 0 to t and the second from t to 1
+func (h Hermite) Split(t float64) (Hermite, Hermite) {
+	p, d := h.At(t), h.Derivative(t)
+
+	return Hermite{
+		P0: clone(h.P0), T0: scale(clone(h.T0), t),
+		P1: p, T1: scale(clone(d), t),
+	}, Hermite{
+		P0: clone(p), T0: scale(clone(d), 1-t),
+		P1: clone(h.P1), T1: scale(clone(h.T1), 1-t),
+	}
+}
+
+// Bounds returns the exact componentwise minimum and maximum of the curve
+func (h Hermite) Bounds() (min, max Vector) {
+	return h.Bezier().Bounds()
+}
+
+// Flatten returns a polyline that stays within the tolerance of the curve
+func (h Hermite) Flatten(tolerance float64) []Vector {
+	return h.Bezier().Flatten(tolerance)
+}
+
+// Bezier returns the curve as the equivalent cubic Bézier curve
+func (h Hermite) Bezier() Bezier {
+	dim := len(h.P0)
+	p0, p1 := resize(h.P0, dim), resize(h.P1, dim)
+	b1, b2 := make(Vector, dim), make(Vector, dim)
+
+	axpyUnitaryTo(b1, 1./3, resize(h.T0, dim), p0)
+	axpyUnitaryTo(b2, -1./3, resize(h.T1, dim), p1)
+
+	return Bezier{p0, b1, b2, p1}
+}
+
+// CatmullRom is a Catmull-Rom spline passing through all of its points. Alpha
+// decides the parameterization, which is usually CatmullRomUniform,
+// CatmullRomCentripetal or CatmullRomChordal. The spline is extended beyond the first and last point by
+// mirroring their neighbours, so it also passes through the end points.
+//
+// The parameter t is spread evenly over the segments between the points, so
+// only the uniform parameterization has a continuous derivative where the
+// segments meet.
+type CatmullRom struct {
+	Points []Vector
+	Alpha  CatmullRomAlpha
+}
+
+// At returns the point on the spline at t
+func (c CatmullRom) At(t float64) Vector {
+	segments := c.Hermites()
+	if len(segments) == 0 {
+		return clone(Bezier(c.Points).point(0))
+	}
+
+	i, u := segment(len(segments), t)
+	return segments[i].At(u)
+}
+
+// Derivative returns the derivative of the spline with respect to t
+func (c CatmullRom) Derivative(t float64) Vector {
+	segments := c.Hermites()
+	if len(segments) == 0 {
+		return make(Vector, len(Bezier(c.Points).point(0)))
+	}
+
+	i, u := segment(len(segments), t)
+	return scale(segments[i].Derivative(u), float64(len(segments)))
+}
+
+// Split divides the spline at t into two curves. A piece of a Catmull-Rom
+// spline is not a Catmull-Rom spline itself, so both pieces are returned as the
+// equivalent cubic B-spline.
+func (c CatmullRom) Split(t float64) (*BSpline, *BSpline) {
+	return c.BSpline().Split(t)
+}
+
+// Bounds returns the exact componentwise minimum and maximum of the spline
+func (c CatmullRom) Bounds() (min, max Vector) {
+	return boundsOf(c.Beziers())
+}
+
+// Flatten returns a polyline that stays within the tolerance of the spline
+func (c CatmullRom) Flatten(tolerance float64) []Vector {
+	return flattenAll(c.Beziers(), tolerance)
+}
+
+// Hermites returns the segments of the spline as Hermite curves
+func (c CatmullRom) Hermites() []Hermite {
+	n := len(c.Points)
+	if n < 2 {
+		return nil
+	}
+
+	dim := len(c.Points[0])
+	p := make([]Vector, n+2)
+	for i := range c.Points {
+		p[i+1] = resize(c.Points[i], dim)
+	}
+	p[0] = sub(scale(clone(p[1]), 2), p[2])
+	p[n+1] = sub(scale(clone(p[n]), 2), p[n-1])
+
+	// the knot sequence, where coincident points are given a tiny distance to
+	// avoid dividing by zero
+	knots := make(Vector, len(p))
+	for i := 1; i < len(p); i++ {
+		d := math.Pow(Euclidean(p[i], p[i-1]), float64(c.Alpha))
+		knots[i] = knots[i-1] + math.Max(d, 1e-12)
+	}
+
+	segments := make([]Hermite, n-1)
+	for i := range segments {
+		p0, p1, p2, p3 := p[i], p[i+1], p[i+2], p[i+3]
+		t0, t1, t2, t3 := knots[i], knots[i+1], knots[i+2], knots[i+3]
+
+		m1 := make(Vector, dim)
+		axpyUnitaryTo(m1, 1/(t1-t0), sub(clone(p1), p0), m1)
+		axpyUnitaryTo(m1, -1/(t2-t0), sub(clone(p2), p0), m1)
+		axpyUnitaryTo(m1, 1/(t2-t1), sub(clone(p2), p1), m1)
+
+		m2 := make(Vector, dim)
+		axpyUnitaryTo(m2, 1/(t2-t1), sub(clone(p2), p1), m2)
+		axpyUnitaryTo(m2, -1/(t3-t1), sub(clone(p3), p1), m2)
+		axpyUnitaryTo(m2, 1/(t3-t2), sub(clone(p3), p2), m2)
+
+		segments[i] = Hermite{
+			P0: clone(p1), T0: scale(m1, t2-t1),
+			P1: clone(p2), T1: scale(m2, t2-t1),
+		}
+	}
+
+	return segments
+}
+
+// Beziers returns the segments of the spline as cubic Bézier curves
+func (c CatmullRom) Beziers() []Bezier {
+	segments := c.Hermites()
+	beziers := make([]Bezier, len(segments))
+	for i := range segments {
+		beziers[i] = segments[i].Bezier()
+	}
+	return beziers
+}
+
+// BSpline returns the spline as the equivalent cubic B-spline
+func (c CatmullRom) BSpline() *BSpline {
+	return bsplineFromBeziers(c.Beziers())
+}
+
+// BSpline is a B-spline curve of the given degree, with a control point for
+// every basis function and a non-decreasing knot vector with the length of the
+// number of points plus the degree plus one.
+//
+// The parameter t between 0 and 1 is mapped onto the valid range of the knot
+// vector, from knot degree to knot len(Points).
+type BSpline struct {
+	Degree int
+	Points []Vector
+	Knots  Vector
+}
+
+// NewBSpline returns a B-spline of the given degree with a clamped uniform knot
+// vector, so the spline starts in the first and ends in the last point.
+func NewBSpline(degree int, points []Vector) *BSpline {
+	if degree > len(points)-1 {
+		degree = len(points) - 1
+	}
+
+	if degree < 0 {
+		degree = 0
+	}
+
+	n := len(points)
+	knots := make(Vector, n+degree+1)
+	for i := range knots {
+		switch {
+		case i <= degree:
+			knots[i] = 0
+		case i >= n:
+			knots[i] = 1
+		default:
+			knots[i] = float64(i-degree) / float64(n-degree)
+		}
+	}
+
+	return &BSpline{Degree: degree, Points: cloneMatrix(points), Knots: knots}
+}
+
+// At returns the point on the spline at t using de Boor's algorithm
+func (b *BSpline) At(t float64) Vector {
+	if len(b.Points) == 0 {
+		return nil
+	}
+
+	u := b.parameter(t)
+	k, p := b.span(u), b.Degree
+
+	d := make([]Vector, p+1)
+	for j := range d {
+		d[j] = resize(b.Points[j+k-p], len(b.Points[0]))
+	}
+
+	for r := 1; r <= p; r++ {
+		for j := p; j >= r; j-- {
+			lo, hi := b.Knots[j+k-p], b.Knots[j+1+k-r]
+			alpha := 0.
+			if hi > lo {
+				alpha = (u - lo) / (hi - lo)
+			}
+			for i := range d[j] {
+				d[j][i] = (1-alpha)*d[j-1][i] + alpha*d[j][i]
+			}
+		}
+	}
+
+	return d[p]
+}
+
+// Derivative returns the derivative of the spline with respect to t
+func (b *BSpline) Derivative(t float64) Vector {
+	if len(b.Points) == 0 {
+		return nil
+	}
+
+	if b.Degree == 0 || len(b.Points) < 2 {
+		return make(Vector, len(b.Points[0]))
+	}
+
+	p := b.Degree
+	d := &BSpline{
+		Degree: p - 1,
+		Points: make([]Vector, len(b.Points)-1),
+		Knots:  b.Knots[1 : len(b.Knots)-1],
+	}
+
+	for i := range d.Points {
+		span := b.Knots[i+p+1] - b.Knots[i+1]
+		d.Points[i] = sub(resize(b.Points[i+1], len(b.Points[0])), b.Points[i])
+		if span > 0 {
+			scale(d.Points[i], float64(p)/span)
+		} else {
+			scale(d.Points[i], 0)
+		}
+	}
+
+	lo, hi := b.domain()
+	return scale(d.At(t), hi-lo)
+}
+
+// Split divides the spline at t into two B-splines by inserting knots at t,
+// where the first covers the spline from 0 to t and the second from t to 1
+func (b *BSpline) Split(t float64) (*BSpline, *BSpline) {
+	lo, hi := b.domain()
+	u, p := b.parameter(t), b.Degree
+
+	if u <= lo || u >= hi || p == 0 {
+		point := []Vector{b.At(t)}
+		if u <= lo {
+			return NewBSpline(0, point), b.clone()
+		}
+		return b.clone(), NewBSpline(0, point)
+	}
+
+	s := b.clone()
+	for s.multiplicity(u) < p {
+		s.insert(u)
+	}
+
+	j := sort.SearchFloat64s(s.Knots, u)
+
+	left := &BSpline{
+		Degree: p,
+		Points: cloneMatrix(s.Points[:j]),
+		Knots:  append(clone(s.Knots[:j+p]), u),
+	}
+
+	right := &BSpline{
+		Degree: p,
+		Points: cloneMatrix(s.Points[j-1:]),
+		Knots:  append(Vector{u}, s.Knots[j:]...),
+	}
+
+	return left, right
+}
+
+// Bounds returns the exact componentwise minimum and maximum of the spline
+func (b *BSpline) Bounds() (min, max Vector) {
+	return boundsOf(b.Beziers())
+}
+
+// Flatten returns a polyline that stays within the tolerance of the spline
+func (b *BSpline) Flatten(tolerance float64) []Vector {
+	return flattenAll(b.Beziers(), tolerance)
+}
+
+// Beziers returns the spline as a list of Bézier curves of the same degree, by
+// inserting every knot within the domain, including its ends, until it has the
+// multiplicity of the degree
+func (b *BSpline) Beziers() []Bezier {
+	if len(b.Points) == 0 {
+		return nil
+	}
+
+	lo, hi := b.domain()
+	p := b.Degree
+
+	if p == 0 || hi <= lo {
+		return []Bezier{{b.At(0)}}
+	}
+
+	s := b.clone()
+	for _, u := range b.Knots {
+		if u >= lo && u <= hi {
+			for s.multiplicity(u) < p {
+				s.insert(u)
+			}
+		}
+	}
+
+	var beziers []Bezier
+	for i := p; i+1 < len(s.Knots)-p; i++ {
+		if s.Knots[i] >= lo && s.Knots[i+1] <= hi && s.Knots[i+1] > s.Knots[i] {
+			beziers = append(beziers, Bezier(cloneMatrix(s.Points[i-p:i+1])))
+		}
+	}
+
+	return beziers
+}
+
+func (b *BSpline) clone() *BSpline {
+	return &BSpline{Degree: b.Degree, Points: cloneMatrix(b.Points), Knots: clone(b.Knots)}
+}
+
+// domain returns the valid range of the knot vector
+func (b *BSpline) domain() (float64, float64) {
+	return b.Knots[b.Degree], b.Knots[len(b.Points)]
+}
+
+// parameter maps t between 0 and 1 onto the domain of the knot vector
+func (b *BSpline) parameter(t float64) float64 {
+	lo, hi := b.domain()
+	return lo + math.Max(0, math.Min(1, t))*(hi-lo)
+}
+
+// span returns the index k of the knot span with knot k <= u < knot k+1,
+// where u at the end of the domain belongs to the last non-empty span
+func (b *BSpline) span(u float64) int {
+	n, p := len(b.Points), b.Degree
+	if u >= b.Knots[n] {
+		k := n - 1
+		for k > p && b.Knots[k] >= b.Knots[n] {
+			k--
+		}
+		return k
+	}
+
+	return sort.Search(len(b.Knots), func(i int) bool { return b.Knots[i] > u }) - 1
+}
+
+func (b *BSpline) multiplicity(u float64) int {
+	var m int
+	for _, k := range b.Knots {
+		if k == u {
+			m++
+		}
+	}
+	return m
+}
+
+// insert inserts the knot u once with Boehm's algorithm
+func (b *BSpline) insert(u float64) {
+	k, p := b.span(u), b.Degree
+	points := make([]Vector, len(b.Points)+1)
+
+	for i := range points {
+		switch {
+		case i <= k-p:
+			points[i] = clone(b.Points[i])
+		case i <= k:
+			alpha := (u - b.Knots[i]) / (b.Knots[i+p] - b.Knots[i])
+			points[i] = lerp(clone(b.Points[i-1]), b.Points[i], alpha)
+		default:
+			points[i] = clone(b.Points[i-1])
+		}
+	}
+
+	knots := make(Vector, 0, len(b.Knots)+1)
+	knots = append(knots, b.Knots[:k+1]...)
+	knots = append(knots, u)
+	knots = append(knots, b.Knots[k+1:]...)
+
+	b.Points, b.Knots = points, knots
+}
+
+// bsplineFromBeziers joins cubic Bézier curves, where each curve starts where
+// the previous ended, into a single B-spline with a triple knot at each joint
+func bsplineFromBeziers(beziers []Bezier) *BSpline {
+	if len(beziers) == 0 {
+		return &BSpline{Degree: 0}
+	}
+
+	p, m := len(beziers[0])-1, len(beziers)
+	points := []Vector{clone(beziers[0][0])}
+	knots := make(Vector, 0, m*p+p+2)
+
+	for i := 0; i <= p; i++ {
+		knots = append(knots, 0)
+	}
+
+	for i, b := range beziers {
+		for _, point := range b[1:] {
+			points = append(points, clone(point))
+		}
+
+		for j := 0; j < p; j++ {
+			knots = append(knots, float64(i+1)/float64(m))
+		}
+	}
+
+	knots = append(knots, 1)
+
+	return &BSpline{Degree: p, Points: points, Knots: knots}
+}
+
+func boundsOf(beziers []Bezier) (min, max Vector) {
+	for i, b := range beziers {
+		lo, hi := b.Bounds()
+		if i == 0 {
+			min, max = lo, hi
+			continue
+		}
+		extend(min, max, lo)
+		extend(min, max, hi)
+	}
+	return min, max
+}
+
+func flattenAll(beziers []Bezier, tolerance float64) []Vector {
+	result := []Vector{}
+	for i, b := range beziers {
+		points := b.Flatten(tolerance)
+		if i > 0 {
+			points = points[1:]
+		}
+		result = append(result, points...)
+	}
+	return result
+}
+
+// segment maps t between 0 and 1 onto one of n segments, returning the index
+// of the segment and the parameter within it
+func segment(n int, t float64) (int, float64) {
+	t = math.Max(0, math.Min(1, t)) * float64(n)
+	i := int(math.Floor(t))
+	if i >= n {
+		i = n - 1
+	}
+	return i, t - float64(i)
+}
+
+// bernsteinRoots finds the roots of a polynomial in Bernstein form on the
+// interval lo to hi, by subdividing it until the sign of the coefficients
+// rules out a root or the interval is small enough
+func bernsteinRoots(c []float64, lo, hi float64, depth int, roots *[]float64) {
+	min, max := c[0], c[0]
+	for _, v := range c {
+		min, max = math.Min(min, v), math.Max(max, v)
+	}
+
+	if min > 0 || max < 0 || (min == 0 && max == 0) {
+		return
+	}
+
+	if depth >= 52 || hi-lo < 1e-12 {
+		t := (lo + hi) / 2
+		if n := len(*roots); n == 0 || t-(*roots)[n-1] > 1e-9 {
+			*roots = append(*roots, t)
+		}
+		return
+	}
+
+	n := len(c)
+	left, right, points := make([]float64, n), make([]float64, n), append([]float64{}, c...)
+	for i := 0; i < n; i++ {
+		left[i], right[n-1-i] = points[0], points[n-1-i]
+		for j := 0; j < n-1-i; j++ {
+			points[j] = (points[j] + points[j+1]) / 2
+		}
+	}
+
+	mid := (lo + hi) / 2
+	bernsteinRoots(left, lo, mid, depth+1, roots)
+	bernsteinRoots(right, mid, hi, depth+1, roots)
+}
+
+// lerp moves a towards b by the fraction t in place and returns it
+func lerp(a, b []float64, t float64) []float64 {
+	for i := range a {
+		a[i] += (component(b, i) - a[i]) * t
+	}
+	return a
+}
+
+// extend grows the bounds given by min and max to contain the point
+func extend(min, max, point []float64) {
+	for i := range min {
+		v := component(point, i)
+		min[i], max[i] = math.Min(min[i], v), math.Max(max[i], v)
+	}
+}
+
+// segmentDistance returns the distance from the point p to the line segment
+// from a to b
+func segmentDistance(p, a, b []float64) float64 {
+	ab, ap := sub(resize(b, len(a)), a), sub(resize(p, len(a)), a)
+	l := dot(ab, ab)
+
+	if l == 0 {
+		return magnitude(ap)
+	}
+
+	t := math.Max(0, math.Min(1, dot(ap, ab)/l))
+	return magnitude(sub(ap, scale(ab, t)))
+}
@@ -0,0 +1,157 @@
+package vector_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func assertDerivative(t *testing.T, name string, c vector.Curve) {
+	t.Helper()
+
+	const h = 1e-6
+	for _, u := range []float64{0.1, 0.35, 0.55, 0.8} {
+		numeric := c.At(u + h).Sub(c.At(u - h)).Scale(1 / (2 * h))
+		if numeric.Sub(c.Derivative(u)).Magnitude() > 1e-4 {
+			t.Errorf("%v derivative at %v is %v, expected %v", name, u, c.Derivative(u), numeric)
+		}
+	}
+}
+
+func assertSplit(t *testing.T, name string, c, left, right vector.Curve, at float64) {
+	t.Helper()
+
+	for _, s := range []float64{0, 0.25, 0.5, 0.75, 1} {
+		if !left.At(s).Equal(c.At(s * at)) {
+			t.Errorf("%v left piece at %v is %v, expected %v", name, s, left.At(s), c.At(s*at))
+		}
+
+		if !right.At(s).Equal(c.At(at + s*(1-at))) {
+			t.Errorf("%v right piece at %v is %v, expected %v", name, s, right.At(s), c.At(at+s*(1-at)))
+		}
+	}
+}
+
+func assertFlatten(t *testing.T, name string, c vector.Curve, tolerance float64) {
+	t.Helper()
+
+	polyline := c.Flatten(tolerance)
+	if !polyline[0].Equal(c.At(0)) || !polyline[len(polyline)-1].Equal(c.At(1)) {
+		t.Errorf("%v flattened polyline does not start and end on the curve", name)
+	}
+
+	for i := 0; i <= 100; i++ {
+		p, closest := c.At(float64(i)/100), math.Inf(1)
+		for j := 1; j < len(polyline); j++ {
+			closest = math.Min(closest, distanceToSegment(p, polyline[j-1], polyline[j]))
+		}
+
+		if closest > tolerance*1.01 {
+			t.Errorf("%v point %v is %v from the flattened polyline", name, p, closest)
+		}
+	}
+}
+
+func assertBounds(t *testing.T, name string, c vector.Curve) {
+	t.Helper()
+
+	min, max := c.Bounds()
+	for i := 0; i <= 1000; i++ {
+		p := c.At(float64(i) / 1000)
+		for j := range p {
+			if p[j] < min[j]-1e-9 || p[j] > max[j]+1e-9 {
+				t.Fatalf("%v point %v lies outside the bounds %v %v", name, p, min, max)
+			}
+		}
+	}
+}
+
+func distanceToSegment(p, a, b vec) float64 {
+	ab, ap := b.Sub(a), p.Sub(a)
+	u := math.Max(0, math.Min(1, ap.Dot(ab)/ab.Dot(ab)))
+	return ap.Sub(ab.Scale(u)).Magnitude()
+}
+
+func TestBezier(t *testing.T) {
+	b := vector.Bezier{{0, 0}, {1, 2}, {2, 0}}
+
+	if !b.At(0.5).Equal(vec{1, 1}) {
+		t.Errorf("unexpected point %v", b.At(0.5))
+	}
+
+	if min, max := b.Bounds(); !min.Equal(vec{0, 0}) || !max.Equal(vec{2, 1}) {
+		t.Errorf("unexpected bounds %v %v", min, max)
+	}
+
+	quintic := vector.Bezier{{0, 0, 0}, {1, 3, -1}, {2, -2, 4}, {3, 5, 0}, {5, 0, 1}, {4, 4, 4}}
+	left, right := quintic.Split(0.3)
+
+	assertDerivative(t, "Bezier", quintic)
+	assertSplit(t, "Bezier", quintic, left, right, 0.3)
+	assertFlatten(t, "Bezier", quintic, 0.01)
+	assertBounds(t, "Bezier", quintic)
+}
+
+func TestHermite(t *testing.T) {
+	h := vector.Hermite{P0: vec{0, 0}, T0: vec{3, 0}, P1: vec{1, 1}, T1: vec{0, 3}}
+
+	if !h.Derivative(0).Equal(vec{3, 0}) || !h.Derivative(1).Equal(vec{0, 3}) {
+		t.Error("derivatives at the end points differ from the tangents")
+	}
+
+	left, right := h.Split(0.6)
+
+	assertDerivative(t, "Hermite", h)
+	assertSplit(t, "Hermite", h, left, right, 0.6)
+	assertFlatten(t, "Hermite", h, 0.001)
+	assertBounds(t, "Hermite", h)
+}
+
+func TestCatmullRom(t *testing.T) {
+	points := []vec{{0, 0}, {1, 2}, {1.1, 2}, {4, 0}, {5, 3}}
+
+	for _, alpha := range []vector.CatmullRomAlpha{vector.CatmullRomUniform, vector.CatmullRomCentripetal, vector.CatmullRomChordal} {
+		c := vector.CatmullRom{Points: points, Alpha: alpha}
+
+		for i, p := range points {
+			if !c.At(float64(i) / 4).Equal(p) {
+				t.Errorf("spline with alpha %v does not pass through %v", alpha, p)
+			}
+		}
+
+		left, right := c.Split(0.45)
+
+		assertDerivative(t, "CatmullRom", c)
+		assertSplit(t, "CatmullRom", c, left, right, 0.45)
+		assertFlatten(t, "CatmullRom", c, 0.01)
+		assertBounds(t, "CatmullRom", c)
+	}
+}
+
+func TestBSpline(t *testing.T) {
+	points := []vec{{0, 0, 0}, {1, 2, 0}, {2, -1, 1}, {3, 3, 2}, {4, 0, 0}, {5, 1, 1}, {6, 0, 0}}
+
+	cubic := vector.NewBSpline(3, points[:4])
+	if !cubic.At(0.3).Equal(vector.Bezier(points[:4]).At(0.3)) {
+		t.Error("clamped cubic B-spline with four points differs from the Bézier curve")
+	}
+
+	for _, degree := range []int{1, 2, 3, 4} {
+		b := vector.NewBSpline(degree, points)
+
+		if !b.At(0).Equal(points[0]) || !b.At(1).Equal(points[len(points)-1]) {
+			t.Errorf("degree %v spline does not start and end in the end points", degree)
+		}
+
+		left, right := b.Split(0.42)
+
+		assertSplit(t, "BSpline", b, left, right, 0.42)
+		assertFlatten(t, "BSpline", b, 0.01)
+		assertBounds(t, "BSpline", b)
+
+		if degree > 1 {
+			assertDerivative(t, "BSpline", b)
+		}
+	}
+}
@@ -0,0 +1,458 @@
+package vector
+
+import (
+	"math"
+	"sort"
+)
+
+// Noise is the cluster label given to vectors that do not belong to any
+// cluster by DBSCAN and HDBSCAN
+const Noise = -1
+
+// DBSCAN clusters a dataset by density, where a cluster is a maximal set of
+// vectors that are reachable from each other through core vectors, which are
+// vectors with at least minPoints vectors, including themselves, within the
+// radius eps. If metric is nil it defaults to Euclidean.
+//
+// It returns a label per vector, where clusters are numbered from 0 in the
+// order they are found and vectors not belonging to any cluster are labelled
+// Noise. Neighbours are looked up with a VPTree, so the metric must satisfy
+// the triangle inequality unless linear is set, see NewVPTree.
+func DBSCAN(data []Vector, eps float64, minPoints int, metric Metric, linear bool) []int {
+	tree := NewVPTree(data, metric, linear)
+	labels := make([]int, len(data))
+
+	// queued marks the vectors that have been queued for expansion, so every
+	// vector is queued at most once
+	queued := make([]bool, len(data))
+
+	for i := range labels {
+		labels[i] = Noise
+	}
+
+	cluster := 0
+	for i := range data {
+		if queued[i] {
+			continue
+		}
+		queued[i] = true
+
+		neighbours := tree.Within(data[i], eps)
+		if len(neighbours) < minPoints {
+			continue
+		}
+
+		labels[i] = cluster
+		queue := []int{}
+
+		expand := func(neighbours []int) {
+			for _, j := range neighbours {
+				if labels[j] == Noise {
+					labels[j] = cluster
+				}
+
+				if !queued[j] {
+					queued[j] = true
+					queue = append(queue, j)
+				}
+			}
+		}
+
+		for expand(neighbours); len(queue) > 0; queue = queue[1:] {
+			j := queue[0]
+
+			if n := tree.Within(data[j], eps); len(n) >= minPoints {
+				expand(n)
+			}
+		}
+
+		cluster++
+	}
+
+	return labels
+}
+
+// HDBSCAN clusters a dataset by density without a fixed radius, by building
+// the hierarchy of all DBSCAN clusterings and picking the most stable clusters
+// from it. Clusters smaller than minClusterSize are treated as noise, and
+// minSamples is the number of neighbours, including the vector itself, needed
+// for a vector to be a core vector. If metric is nil it defaults to Euclidean.
+//
+// It returns a label per vector in the same form as DBSCAN. Like DBSCAN the
+// metric must satisfy the triangle inequality unless linear is set.
+func HDBSCAN(data []Vector, minClusterSize, minSamples int, metric Metric, linear bool) []int {
+	n := len(data)
+	labels := make([]int, n)
+
+	for i := range labels {
+		labels[i] = Noise
+	}
+
+	if n < 2 || minClusterSize > n {
+		return labels
+	}
+
+	if metric == nil {
+		metric = Euclidean
+	}
+
+	if minClusterSize < 2 {
+		minClusterSize = 2
+	}
+
+	if minSamples < 1 {
+		minSamples = minClusterSize
+	}
+
+	tree := NewVPTree(data, metric, linear)
+	core := make(Vector, n)
+	for i := range data {
+		_, d := tree.Nearest(data[i], minSamples)
+		core[i] = d[len(d)-1]
+	}
+
+	edges := mutualReachabilityTree(tree, core)
+	condensed := condense(singleLinkage(n, edges), n, minClusterSize)
+	selected := selectClusters(condensed)
+
+	ids := make(map[int]int)
+	for c := range selected {
+		if selected[c] {
+			ids[c] = len(ids)
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		for c := condensed.fallout[i]; c >= 0; c = condensed.parent[c] {
+			if selected[c] {
+				labels[i] = ids[c]
+				break
+			}
+		}
+	}
+
+	return labels
+}
+
+type edge struct {
+	a, b     int
+	distance float64
+}
+
+// mutualReachabilityTree returns the minimum spanning tree of the dataset,
+// where the distance between two vectors is the largest of their distance and
+// their two core distances. It uses Borůvka's algorithm, where every round
+// connects each component to its nearest other component with a search in the
+// tree, which takes O(log n) rounds of n searches instead of the O(n²) scan of
+// the complete graph.
+func mutualReachabilityTree(t *VPTree, core Vector) []edge {
+	n := len(t.data)
+	edges := make([]edge, 0, n-1)
+	parent, component, same := make([]int, n), make([]int, n), make([]int, n)
+	best := make([]edge, n)
+
+	for i := range parent {
+		parent[i] = i
+	}
+
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	for len(edges) < n-1 {
+		for i := range component {
+			component[i] = find(i)
+			best[i] = edge{distance: math.Inf(1)}
+		}
+		t.components(t.root, component, same)
+
+		for i := range t.data {
+			c := component[i]
+
+			// every edge from i is at least its core distance
+			if core[i] > best[c].distance {
+				continue
+			}
+
+			if t.linear {
+				for j := range t.data {
+					t.offerEdge(i, j, component, core, &best[c])
+				}
+			} else {
+				t.nearestOther(t.root, i, component, same, core, &best[c])
+			}
+		}
+
+		candidates := []edge{}
+		for i, e := range best {
+			if component[i] == i && !math.IsInf(e.distance, 1) {
+				candidates = append(candidates, e)
+			}
+		}
+
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].less(candidates[j])
+		})
+
+		// distances that are not numbers can leave components unconnected
+		if len(candidates) == 0 {
+			break
+		}
+
+		for _, e := range candidates {
+			if a, b := find(e.a), find(e.b); a != b {
+				parent[a] = b
+				edges = append(edges, e)
+			}
+		}
+	}
+
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].distance < edges[j].distance
+	})
+
+	return edges
+}
+
+// less orders edges by distance, and ties by the vectors they connect, so
+// Borůvka's algorithm picks the same edge from both sides of a tie
+func (e edge) less(f edge) bool {
+	if e.distance != f.distance {
+		return e.distance < f.distance
+	}
+	if e.a != f.a {
+		return e.a < f.a
+	}
+	return e.b < f.b
+}
+
+// components stores for every node of the tree the component all vectors
+// below it belong to, or -1 if they belong to different components
+func (t *VPTree) components(node *vpNode, component, same []int) int {
+	if node == nil {
+		return -2
+	}
+
+	c := component[node.index]
+	if inside := t.components(node.inside, component, same); inside != -2 && inside != c {
+		c = -1
+	}
+	if outside := t.components(node.outside, component, same); outside != -2 && outside != c {
+		c = -1
+	}
+
+	same[node.index] = c
+	return c
+}
+
+// nearestOther searches the tree for the shortest mutual reachability edge
+// from vector i to a vector of another component. The mutual reachability
+// distance is never shorter than the metric, so the tree can prune on it.
+func (t *VPTree) nearestOther(node *vpNode, i int, component, same []int, core Vector, best *edge) {
+	if node == nil || same[node.index] == component[i] {
+		return
+	}
+
+	d := t.offerEdge(i, node.index, component, core, best)
+
+	if d < node.threshold {
+		if d-best.distance <= node.threshold {
+			t.nearestOther(node.inside, i, component, same, core, best)
+		}
+		if d+best.distance >= node.threshold {
+			t.nearestOther(node.outside, i, component, same, core, best)
+		}
+		return
+	}
+
+	if d+best.distance >= node.threshold {
+		t.nearestOther(node.outside, i, component, same, core, best)
+	}
+	if d-best.distance <= node.threshold {
+		t.nearestOther(node.inside, i, component, same, core, best)
+	}
+}
+
+// offerEdge replaces best with the edge between i and j if they are in
+// different components and the edge is shorter, and returns the distance
+// between them
+func (t *VPTree) offerEdge(i, j int, component []int, core Vector, best *edge) float64 {
+	d := t.metric(t.data[i], t.data[j])
+
+	if component[i] != component[j] {
+		e := edge{i, j, math.Max(d, math.Max(core[i], core[j]))}
+		if j < i {
+			e.a, e.b = j, i
+		}
+
+		if e.less(*best) {
+			*best = e
+		}
+	}
+
+	return d
+}
+
+type dendrogram struct {
+	left, right []int
+	distance    Vector
+	size        []int
+}
+
+// singleLinkage turns the sorted spanning tree into a dendrogram, where the
+// leaves are the vectors 0 to n-1 and the merges are the nodes n to 2n-2
+func singleLinkage(n int, edges []edge) dendrogram {
+	d := dendrogram{
+		left:     make([]int, n-1),
+		right:    make([]int, n-1),
+		distance: make(Vector, n-1),
+		size:     make([]int, 2*n-1),
+	}
+
+	parent := make([]int, 2*n-1)
+	for i := range parent {
+		parent[i] = i
+		if i < n {
+			d.size[i] = 1
+		}
+	}
+
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	for i, e := range edges {
+		a, b, node := find(e.a), find(e.b), n+i
+		d.left[i], d.right[i], d.distance[i] = a, b, e.distance
+		d.size[node] = d.size[a] + d.size[b]
+		parent[a], parent[b] = node, node
+	}
+
+	return d
+}
+
+type condensedTree struct {
+	// parent and birth hold the parent cluster and the lambda the cluster
+	// split off at, where lambda is 1 / distance
+	parent []int
+	birth  Vector
+	// stability of every cluster
+	stability Vector
+	// fallout is the cluster each vector fell out of
+	fallout []int
+}
+
+// condense walks the dendrogram from the root and only keeps the splits where
+// both sides have at least minClusterSize vectors, every other split is seen as
+// vectors falling out of the cluster they belong to
+func condense(d dendrogram, n, minClusterSize int) condensedTree {
+	t := condensedTree{
+		parent:    []int{-1},
+		birth:     Vector{0},
+		stability: Vector{0},
+		fallout:   make([]int, n),
+	}
+
+	size := func(node int) int { return d.size[node] }
+	children := func(node int) (int, int, float64) {
+		i := node - n
+		return d.left[i], d.right[i], lambda(d.distance[i])
+	}
+
+	var fall func(node, cluster int, l float64)
+	fall = func(node, cluster int, l float64) {
+		if node < n {
+			t.fallout[node] = cluster
+			t.stability[cluster] += l - t.birth[cluster]
+			return
+		}
+
+		left, right, _ := children(node)
+		fall(left, cluster, l)
+		fall(right, cluster, l)
+	}
+
+	type item struct{ node, cluster int }
+	for queue := []item{{2*n - 2, 0}}; len(queue) > 0; queue = queue[1:] {
+		node, cluster := queue[0].node, queue[0].cluster
+
+		if node < n {
+			fall(node, cluster, t.birth[cluster])
+			continue
+		}
+
+		left, right, l := children(node)
+
+		switch {
+		case size(left) >= minClusterSize && size(right) >= minClusterSize:
+			for _, child := range []int{left, right} {
+				t.stability[cluster] += float64(size(child)) * (l - t.birth[cluster])
+				t.parent = append(t.parent, cluster)
+				t.birth = append(t.birth, l)
+				t.stability = append(t.stability, 0)
+				queue = append(queue, item{child, len(t.parent) - 1})
+			}
+		case size(left) < minClusterSize && size(right) < minClusterSize:
+			fall(left, cluster, l)
+			fall(right, cluster, l)
+		case size(left) < minClusterSize:
+			fall(left, cluster, l)
+			queue = append(queue, item{right, cluster})
+		default:
+			fall(right, cluster, l)
+			queue = append(queue, item{left, cluster})
+		}
+	}
+
+	return t
+}
+
+// selectClusters picks the clusters with the excess of mass method, where a
+// cluster is selected if it is more stable than its selected descendants. The
+// root is never selected.
+func selectClusters(t condensedTree) []bool {
+	selected := make([]bool, len(t.parent))
+	stability := clone(t.stability)
+	descendants := make(Vector, len(t.parent))
+
+	// children always have a higher id than their parent, so walking backwards
+	// visits the children before the parent
+	for c := len(t.parent) - 1; c > 0; c-- {
+		if stability[c] >= descendants[c] {
+			selected[c] = true
+			unselectDescendants(t, selected, c)
+		} else {
+			stability[c] = descendants[c]
+		}
+
+		descendants[t.parent[c]] += stability[c]
+	}
+
+	return selected
+}
+
+func unselectDescendants(t condensedTree, selected []bool, cluster int) {
+	for c := cluster + 1; c < len(t.parent); c++ {
+		for p := t.parent[c]; p > 0; p = t.parent[p] {
+			if p == cluster {
+				selected[c] = false
+				break
+			}
+		}
+	}
+}
+
+func lambda(distance float64) float64 {
+	if distance < 1e-12 {
+		return 1e12
+	}
+	return 1 / distance
+}
@@ -0,0 +1,97 @@
+package vector
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// primTree returns the weight of the minimum spanning tree of the mutual
+// reachability distances with Prim's algorithm on the complete graph
+func primTree(data []Vector, core Vector, metric Metric) float64 {
+	n := len(data)
+	inTree, best := make([]bool, n), make(Vector, n)
+	for i := range best {
+		best[i] = math.Inf(1)
+	}
+
+	weight, current := 0., 0
+	for added := 1; added < n; added++ {
+		inTree[current] = true
+		next := -1
+
+		for i := range data {
+			if inTree[i] {
+				continue
+			}
+
+			d := math.Max(metric(data[current], data[i]), math.Max(core[current], core[i]))
+			best[i] = math.Min(best[i], d)
+
+			if next < 0 || best[i] < best[next] {
+				next = i
+			}
+		}
+
+		weight += best[next]
+		current = next
+	}
+
+	return weight
+}
+
+func TestMutualReachabilityTree(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+
+	// a grid has many edges of the same length, which Borůvka's algorithm must
+	// not turn into cycles
+	grid := []Vector{}
+	for i := 0; i < 15; i++ {
+		for j := 0; j < 15; j++ {
+			grid = append(grid, Vector{float64(i), float64(j)})
+		}
+	}
+
+	random := make([]Vector, 500)
+	for i := range random {
+		random[i] = Vector{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}
+	}
+
+	metrics := []struct {
+		metric Metric
+		linear bool
+	}{
+		{Euclidean, false},
+		{Manhattan, false},
+		{Cosine, true},
+	}
+
+	for _, m := range metrics {
+		metric := m.metric
+		for _, data := range [][]Vector{grid, random} {
+			tree := NewVPTree(data, metric, m.linear)
+			core := make(Vector, len(data))
+			for i := range data {
+				_, d := tree.Nearest(data[i], 3)
+				core[i] = d[len(d)-1]
+			}
+
+			edges := mutualReachabilityTree(tree, core)
+			if len(edges) != len(data)-1 {
+				t.Fatalf("expected %v edges, got %v", len(data)-1, len(edges))
+			}
+
+			weight := 0.
+			for i, e := range edges {
+				weight += e.distance
+				if i > 0 && edges[i-1].distance > e.distance {
+					t.Fatal("expected the edges to be sorted by distance")
+				}
+			}
+
+			if expected := primTree(data, core, metric); math.Abs(weight-expected) > 1e-9*expected {
+				t.Errorf("expected a spanning tree of weight %v, got %v", expected, weight)
+			}
+		}
+	}
+}
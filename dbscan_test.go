@@ -0,0 +1,225 @@
+package vector_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func TestVPTree(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	data := make([]vec, 300)
+	for i := range data {
+		data[i] = vec{r.Float64() - 0.5, r.Float64() - 0.5, r.Float64() - 0.5}
+	}
+
+	squared := func(a, b vec) float64 {
+		d := vector.Euclidean(a, b)
+		return d * d
+	}
+
+	metrics := map[string]struct {
+		metric vector.Metric
+		linear bool
+	}{
+		"Euclidean": {vector.Euclidean, false},
+		"Manhattan": {vector.Manhattan, false},
+		"Chebyshev": {vector.Chebyshev, false},
+		"Angular":   {vector.Angular, false},
+		"Cosine":    {vector.Cosine, true},
+		"Squared":   {squared, true},
+	}
+
+	for name, m := range metrics {
+		metric, tree := m.metric, vector.NewVPTree(data, m.metric, m.linear)
+
+		for q := 0; q < 50; q++ {
+			query := vec{r.Float64() - 0.5, r.Float64() - 0.5, r.Float64() - 0.5}
+
+			distances := make([]float64, len(data))
+			for i, v := range data {
+				distances[i] = metric(query, v)
+			}
+
+			sorted := append([]float64{}, distances...)
+			sort.Float64s(sorted)
+			radius := sorted[20]
+
+			var expected []int
+			for i := range data {
+				if distances[i] <= radius {
+					expected = append(expected, i)
+				}
+			}
+
+			within := tree.Within(query, radius)
+			sort.Ints(within)
+
+			if len(within) != len(expected) {
+				t.Fatalf("%v: expected %v vectors within radius, got %v", name, len(expected), len(within))
+			}
+
+			for i := range within {
+				if within[i] != expected[i] {
+					t.Fatalf("%v: radius query returned %v, expected %v", name, within, expected)
+				}
+			}
+
+			_, nearest := tree.Nearest(query, 5)
+			for i := range nearest {
+				if math.Abs(nearest[i]-sorted[i]) > 1e-12 {
+					t.Fatalf("%v: nearest neighbour %v has distance %v, expected %v", name, i, nearest[i], sorted[i])
+				}
+			}
+		}
+	}
+}
+
+func TestAngular(t *testing.T) {
+	cases := []struct {
+		a, b     vec
+		expected float64
+	}{
+		{vec{1, 0}, vec{2, 0}, 0},
+		{vec{1, 0}, vec{0, 3}, math.Pi / 2},
+		{vec{1, 0}, vec{-1, 0}, math.Pi},
+		{vec{1, 1}, vec{1}, math.Pi / 4},
+		{vec{1, 0}, vec{1, 1e-9}, 1e-9},
+	}
+
+	for _, c := range cases {
+		if d := vector.Angular(c.a, c.b); math.Abs(d-c.expected) > 1e-15 {
+			t.Errorf("expected the angle between %v and %v to be %v, got %v", c.a, c.b, c.expected, d)
+		}
+	}
+}
+
+func TestDBSCAN(t *testing.T) {
+	data := append(blobs(30, vec{0, 0}, vec{10, 10}), vec{5, -20}, vec{-20, 5})
+
+	labels := vector.DBSCAN(data, 1, 4, nil, false)
+
+	assertClusters(t, vector.Clustering{Assignments: labels}, 30, 2)
+
+	if labels[0] == labels[30] || labels[0] == vector.Noise {
+		t.Errorf("expected two separate clusters, got labels %v and %v", labels[0], labels[30])
+	}
+
+	if labels[60] != vector.Noise || labels[61] != vector.Noise {
+		t.Error("outliers were not marked as noise")
+	}
+}
+
+// dbscan is the textbook DBSCAN with brute force neighbour lookups
+func dbscan(data []vec, eps float64, minPoints int) []int {
+	labels, visited := make([]int, len(data)), make([]bool, len(data))
+	for i := range labels {
+		labels[i] = vector.Noise
+	}
+
+	within := func(i int) []int {
+		var result []int
+		for j := range data {
+			if vector.Euclidean(data[i], data[j]) <= eps {
+				result = append(result, j)
+			}
+		}
+		return result
+	}
+
+	cluster := 0
+	for i := range data {
+		if visited[i] {
+			continue
+		}
+		visited[i] = true
+
+		if len(within(i)) < minPoints {
+			continue
+		}
+
+		labels[i] = cluster
+		for queue := within(i); len(queue) > 0; queue = queue[1:] {
+			j := queue[0]
+			if labels[j] == vector.Noise {
+				labels[j] = cluster
+			}
+			if !visited[j] {
+				visited[j] = true
+				if n := within(j); len(n) >= minPoints {
+					queue = append(queue, n...)
+				}
+			}
+		}
+		cluster++
+	}
+
+	return labels
+}
+
+func TestDBSCANReference(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	data := make([]vec, 400)
+	for i := range data {
+		data[i] = vec{r.Float64() * 10, r.Float64() * 10}
+	}
+
+	expected, labels := dbscan(data, 0.6, 5), vector.DBSCAN(data, 0.6, 5, nil, false)
+	for i := range labels {
+		if labels[i] != expected[i] {
+			t.Fatalf("expected the labels of the brute force DBSCAN, vector %v got %v instead of %v", i, labels[i], expected[i])
+		}
+	}
+
+	// a dense cluster where every vector is a neighbour of every other
+	dense := make([]vec, 2000)
+	for i := range dense {
+		dense[i] = vec{r.Float64(), r.Float64()}
+	}
+
+	for i, l := range vector.DBSCAN(dense, 2, 5, nil, false) {
+		if l != 0 {
+			t.Fatalf("expected a single cluster, vector %v got label %v", i, l)
+		}
+	}
+}
+
+func TestHDBSCAN(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	data := blobs(40, vec{0, 0}, vec{10, 10})
+
+	// a third, much sparser cluster which a single DBSCAN radius fits poorly
+	for i := 0; i < 40; i++ {
+		data = append(data, vec{-20 + r.NormFloat64()*2, 20 + r.NormFloat64()*2})
+	}
+	data = append(data, vec{40, -40})
+
+	labels := vector.HDBSCAN(data, 10, 5, nil, false)
+
+	seen := map[int]bool{}
+	for c := 0; c < 3; c++ {
+		counts := map[int]int{}
+		for _, l := range labels[c*40 : (c+1)*40] {
+			counts[l]++
+		}
+
+		best, label := 0, vector.Noise
+		for l, n := range counts {
+			if n > best {
+				best, label = n, l
+			}
+		}
+
+		if label == vector.Noise || best < 35 || seen[label] {
+			t.Errorf("blob %v was not found as its own cluster, labels %v", c, labels[c*40:(c+1)*40])
+		}
+		seen[label] = true
+	}
+
+	if labels[120] != vector.Noise {
+		t.Error("outlier was not marked as noise")
+	}
+}
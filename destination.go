@@ -0,0 +1,177 @@
+package vector
+
+import "math"
+
+// The ...To functions write the result of an operation into a destination
+// vector instead of cloning or overwriting their operands, so hot loops can
+// reuse a buffer without allocating. Like the gonum kernels the destination
+// comes first and is returned. It is resized to the dimension of the result
+// within its capacity, and only a destination that is too small is replaced
+// by a new vector, so the returned vector must be used instead of dst. The
+// destination may be one of the operands, but it must not partially overlap
+// them.
+
+// AddTo writes the sum of a and b into dst
+func AddTo(dst MutableVector, a, b Vector) MutableVector {
+	dst = destination(dst, len(a))
+	n := overlap(a, b)
+	axpyUnitaryTo(dst[:n], 1, b[:n], a[:n])
+	copy(dst[n:], a[n:])
+	return dst
+}
+
+// SubTo writes the difference of a and b into dst
+func SubTo(dst MutableVector, a, b Vector) MutableVector {
+	dst = destination(dst, len(a))
+	n := overlap(a, b)
+	axpyUnitaryTo(dst[:n], -1, b[:n], a[:n])
+	copy(dst[n:], a[n:])
+	return dst
+}
+
+// InvertTo writes the inverse of a into dst
+func InvertTo(dst MutableVector, a Vector) MutableVector {
+	return ScaleTo(dst, a, -1)
+}
+
+// ScaleTo writes a multiplied by size into dst
+func ScaleTo(dst MutableVector, a Vector, size float64) MutableVector {
+	dst = destination(dst, len(a))
+	scalUnitaryTo(dst, size, a)
+	return dst
+}
+
+// UnitTo writes the direction of a with the length of one into dst. A vector
+// too short to have a direction is copied unchanged, like Unit.
+func UnitTo(dst MutableVector, a Vector) MutableVector {
+	dst = destination(dst, len(a))
+	l := magnitude(a)
+
+	if l < 1e-8 {
+		copy(dst, a)
+		return dst
+	}
+
+	for i := range a {
+		dst[i] = a[i] / l
+	}
+	return dst
+}
+
+// CrossTo writes the cross product of a and b into dst, and returns
+// ErrNot3Dimensional if one of them is not 3-dimensional
+func CrossTo(dst MutableVector, a, b Vector) (MutableVector, error) {
+	if len(a) != 3 || len(b) != 3 {
+		return dst, ErrNot3Dimensional
+	}
+
+	dst = destination(dst, 3)
+	dst[x], dst[y], dst[z] = a[y]*b[z]-b[y]*a[z], a[z]*b[x]-b[z]*a[x], a[x]*b[y]-b[x]*a[y]
+	return dst, nil
+}
+
+// RotateTo writes a rotated by angle around an axis into dst, where the axis
+// defaults to the Z axis. Like Rotate, vectors with fewer than 3 dimensions
+// rotated around the Z axis stay 2-dimensional and all other results are
+// 3-dimensional.
+func RotateTo(dst MutableVector, a Vector, angle float64, axis ...Vector) MutableVector {
+	as := Z
+
+	if len(axis) > 0 {
+		as = axis[0]
+	}
+
+	n := 3
+	if len(a) == 0 {
+		n = 0
+	} else if len(a) < 3 && equal(as, Z) {
+		n = 2
+	}
+
+	ax, ay, az := component(a, x), component(a, y), component(a, z)
+	ux, uy, uz := component(as, x), component(as, y), component(as, z)
+
+	if l := math.Sqrt(ux*ux + uy*uy + uz*uz); l >= 1e-8 {
+		ux, uy, uz = ux/l, uy/l, uz/l
+	}
+
+	// Rodrigues' rotation formula
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	d := (ux*ax + uy*ay + uz*az) * (1 - cos)
+	rx := ax*cos + (uy*az-uz*ay)*sin + ux*d
+	ry := ay*cos + (uz*ax-ux*az)*sin + uy*d
+	rz := az*cos + (ux*ay-uy*ax)*sin + uz*d
+
+	dst = destination(dst, n)
+	switch n {
+	case 2:
+		dst[x], dst[y] = rx, ry
+	case 3:
+		dst[x], dst[y], dst[z] = rx, ry, rz
+	}
+	return dst
+}
+
+// MulTo writes the elementwise product of a and b into dst, where components
+// missing from b leave a unchanged like Mul
+func MulTo(dst MutableVector, a, b Vector) MutableVector {
+	return zipTo(dst, a, b, func(a, b float64) float64 { return a * b })
+}
+
+// DivTo writes the elementwise quotient of a and b into dst, where components
+// missing from b leave a unchanged like Div
+func DivTo(dst MutableVector, a, b Vector) MutableVector {
+	return zipTo(dst, a, b, func(a, b float64) float64 { return a / b })
+}
+
+// MinTo writes the elementwise minimum of a and b into dst
+func MinTo(dst MutableVector, a, b Vector) MutableVector {
+	return zipTo(dst, a, b, math.Min)
+}
+
+// MaxTo writes the elementwise maximum of a and b into dst
+func MaxTo(dst MutableVector, a, b Vector) MutableVector {
+	return zipTo(dst, a, b, math.Max)
+}
+
+// MapTo writes f applied to every component of a into dst
+func MapTo(dst MutableVector, a Vector, f func(float64) float64) MutableVector {
+	dst = destination(dst, len(a))
+	for i := range a {
+		dst[i] = f(a[i])
+	}
+	return dst
+}
+
+// ApplyTo writes f applied to the components of a and b pairwise into dst,
+// where components missing from b leave a unchanged like Apply
+func ApplyTo(dst MutableVector, a, b Vector, f func(a, b float64) float64) MutableVector {
+	return zipTo(dst, a, b, f)
+}
+
+func zipTo(dst, a, b []float64, f func(a, b float64) float64) []float64 {
+	dst = destination(dst, len(a))
+	n := overlap(a, b)
+	for i := 0; i < n; i++ {
+		dst[i] = f(a[i], b[i])
+	}
+	copy(dst[n:], a[n:])
+	return dst
+}
+
+// destination returns dst with the length n, reusing its memory when the
+// capacity allows it. The components are not cleared.
+func destination(dst []float64, n int) []float64 {
+	if cap(dst) >= n {
+		return dst[:n]
+	}
+	return make([]float64, n)
+}
+
+// overlap returns the number of components a and b have in common
+func overlap(a, b []float64) int {
+	if len(b) < len(a) {
+		return len(b)
+	}
+	return len(a)
+}
@@ -0,0 +1,160 @@
+package vector_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func TestDestination(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	atan := func(a, b float64) float64 { return math.Atan2(a, b) }
+
+	for _, n := range []int{1, 2, 3, 4, 7, 16} {
+		a, b := randomSignal(r, n), randomSignal(r, n)
+		short := randomSignal(r, n/2)
+		dst := func() vector.MutableVector { return make(vector.MutableVector, 0, n) }
+
+		cases := []struct {
+			name     string
+			result   vector.MutableVector
+			expected vec
+		}{
+			{"AddTo", vector.AddTo(dst(), a, b), a.Add(b)},
+			{"AddTo short", vector.AddTo(dst(), a, short), a.Add(short)},
+			{"SubTo", vector.SubTo(dst(), a, b), a.Sub(b)},
+			{"SubTo short", vector.SubTo(dst(), a, short), a.Sub(short)},
+			{"InvertTo", vector.InvertTo(dst(), a), a.Invert()},
+			{"ScaleTo", vector.ScaleTo(dst(), a, 2.5), a.Scale(2.5)},
+			{"UnitTo", vector.UnitTo(dst(), a), a.Unit()},
+			{"RotateTo", vector.RotateTo(dst(), a, 0.7), a.Rotate(0.7)},
+			{"RotateTo axis", vector.RotateTo(dst(), a, 0.7, vec{1, -2, 3}), a.Rotate(0.7, vec{1, -2, 3})},
+			{"RotateTo X", vector.RotateTo(dst(), a, 0.7, vector.X), a.Rotate(0.7, vector.X)},
+			{"MulTo", vector.MulTo(dst(), a, short), a.Mul(short)},
+			{"DivTo", vector.DivTo(dst(), a, b), a.Div(b)},
+			{"MinTo", vector.MinTo(dst(), a, b), a.Min(b)},
+			{"MaxTo", vector.MaxTo(dst(), a, short), a.Max(short)},
+			{"MapTo", vector.MapTo(dst(), a, math.Abs), a.Abs()},
+			{"ApplyTo", vector.ApplyTo(dst(), a, short, atan), a.Apply(short, atan)},
+		}
+
+		for _, c := range cases {
+			if !vec(c.result).Equal(c.expected) {
+				t.Errorf("%v of dimension %v: expected %v, got %v", c.name, n, c.expected, c.result)
+			}
+		}
+	}
+
+	a, b := vec{1, 2, 3}, vec{-4, 5, 0.5}
+	expected, _ := a.Cross(b)
+	if result, err := vector.CrossTo(nil, a, b); err != nil || !vec(result).Equal(expected) {
+		t.Errorf("unexpected cross product %v (%v)", result, err)
+	}
+
+	if _, err := vector.CrossTo(nil, a, vec{1, 2}); err != vector.ErrNot3Dimensional {
+		t.Errorf("expected ErrNot3Dimensional, got %v", err)
+	}
+}
+
+func TestDestinationAliasing(t *testing.T) {
+	a, b := vector.MutableVector{1, 2, 3}, vector.MutableVector{4, 5, 6}
+
+	if vector.AddTo(a, vec(a), vec(b)); !vec(a).Equal(vec{5, 7, 9}) {
+		t.Errorf("expected the destination to be the first operand, got %v", a)
+	}
+
+	if vector.SubTo(b, vec(a), vec(b)); !vec(b).Equal(vec{1, 2, 3}) {
+		t.Errorf("expected the destination to be the second operand, got %v", b)
+	}
+
+	if vector.RotateTo(a, vec(a), math.Pi/2); !vec(a).Equal(vec{-7, 5, 9}) {
+		t.Errorf("expected the rotation to be done in place, got %v", a)
+	}
+
+	if _, err := vector.CrossTo(a, vec(a), vec(b)); err != nil || !vec(a).Equal(vec{-3, 30, -19}) {
+		t.Errorf("expected the cross product to be done in place, got %v (%v)", a, err)
+	}
+}
+
+func TestDestinationSize(t *testing.T) {
+	dst := make(vector.MutableVector, 2, 8)
+
+	if result := vector.AddTo(dst, vec{1, 2, 3, 4}, vec{1}); len(result) != 4 || &result[0] != &dst[0] {
+		t.Error("expected the destination to grow within its capacity")
+	}
+
+	if result := vector.ScaleTo(dst, make(vec, 9), 2); len(result) != 9 || &result[0] == &dst[0] {
+		t.Error("expected a new vector when the destination is too small")
+	}
+
+	if result := vector.RotateTo(dst, vec{1}, math.Pi/2); !vec(result).Equal(vec{0, 1}) {
+		t.Errorf("expected a 2-dimensional rotation, got %v", result)
+	}
+
+	if result := vector.UnitTo(dst, vec{}); len(result) != 0 {
+		t.Errorf("expected an empty vector, got %v", result)
+	}
+}
+
+func TestDestinationAllocations(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{2, 3, 64} {
+		a, b := randomSignal(r, n), randomSignal(r, n)
+
+		// rotations around X make 2-dimensional vectors 3-dimensional
+		dst := make(vector.MutableVector, n, n+1)
+
+		allocs := testing.AllocsPerRun(10, func() {
+			vector.AddTo(dst, a, b)
+			vector.SubTo(dst, a, b)
+			vector.InvertTo(dst, a)
+			vector.ScaleTo(dst, a, 2)
+			vector.UnitTo(dst, a)
+			vector.RotateTo(dst, a, 1)
+			vector.RotateTo(dst, a, 1, vector.X)
+			vector.MulTo(dst, a, b)
+			vector.DivTo(dst, a, b)
+			vector.MinTo(dst, a, b)
+			vector.MaxTo(dst, a, b)
+			vector.MapTo(dst, a, math.Abs)
+			vector.ApplyTo(dst, a, b, math.Atan2)
+		})
+
+		if allocs != 0 {
+			t.Errorf("expected no allocations for dimension %v, got %v", n, allocs)
+		}
+	}
+
+	a, b, dst := vec{1, 2, 3}, vec{4, 5, 6}, make(vector.MutableVector, 3)
+	allocs := testing.AllocsPerRun(10, func() {
+		vector.CrossTo(dst, a, b)
+	})
+
+	if allocs != 0 {
+		t.Errorf("expected no allocations for the cross product, got %v", allocs)
+	}
+}
+
+func BenchmarkAddTo(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	x, y := randomSignal(r, 1024), randomSignal(r, 1024)
+	dst := make(vector.MutableVector, 1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vector.AddTo(dst, x, y)
+	}
+}
+
+func BenchmarkAdd(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	x, y := randomSignal(r, 1024), randomSignal(r, 1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Add(y)
+	}
+}
@@ -0,0 +1,334 @@
+package vector
+
+import "math"
+
+// Dual is a dual number, which carries a value together with its partial
+// derivatives with respect to every input of a function. Arithmetic on dual
+// numbers applies the chain rule, so evaluating a function on them gives its
+// gradient alongside its value, which is forward-mode automatic
+// differentiation.
+//
+// A nil Derivative is a constant, and derivatives of different lengths are
+// combined as if the missing partials were zero.
+type Dual struct {
+	Value      float64
+	Derivative Vector
+}
+
+// Constant returns a dual number with a zero derivative
+func Constant(v float64) Dual {
+	return Dual{Value: v}
+}
+
+// Add returns the sum of two dual numbers
+func (a Dual) Add(b Dual) Dual {
+	return Dual{a.Value + b.Value, combine(1, a.Derivative, 1, b.Derivative)}
+}
+
+// Sub returns the difference of two dual numbers
+func (a Dual) Sub(b Dual) Dual {
+	return Dual{a.Value - b.Value, combine(1, a.Derivative, -1, b.Derivative)}
+}
+
+// Mul returns the product of two dual numbers
+func (a Dual) Mul(b Dual) Dual {
+	return Dual{a.Value * b.Value, combine(b.Value, a.Derivative, a.Value, b.Derivative)}
+}
+
+// Div returns the quotient of two dual numbers
+func (a Dual) Div(b Dual) Dual {
+	return Dual{a.Value / b.Value, combine(1/b.Value, a.Derivative, -a.Value/(b.Value*b.Value), b.Derivative)}
+}
+
+// Scale returns the dual number multiplied by a constant
+func (a Dual) Scale(size float64) Dual {
+	return Dual{a.Value * size, combine(size, a.Derivative, 0, nil)}
+}
+
+// Sqrt returns the square root of the dual number, where the derivative at zero
+// is taken as zero instead of infinite
+func (a Dual) Sqrt() Dual {
+	s := math.Sqrt(a.Value)
+	if s == 0 {
+		return Dual{0, combine(0, a.Derivative, 0, nil)}
+	}
+	return a.chain(s, 1/(2*s))
+}
+
+// Sin returns the sine of the dual number
+func (a Dual) Sin() Dual {
+	sin, cos := math.Sincos(a.Value)
+	return a.chain(sin, cos)
+}
+
+// Cos returns the cosine of the dual number
+func (a Dual) Cos() Dual {
+	sin, cos := math.Sincos(a.Value)
+	return a.chain(cos, -sin)
+}
+
+// Pow returns the dual number raised to a constant power
+func (a Dual) Pow(p float64) Dual {
+	return a.chain(math.Pow(a.Value, p), p*math.Pow(a.Value, p-1))
+}
+
+// chain returns the value of a function of the dual number, with the
+// derivative scaled by the derivative of the function
+func (a Dual) chain(value, derivative float64) Dual {
+	return Dual{value, combine(derivative, a.Derivative, 0, nil)}
+}
+
+// combine returns alpha*a + beta*b as a new slice as long as the longer of the
+// two, or nil if both are constants
+func combine(alpha float64, a Vector, beta float64, b Vector) Vector {
+	n := maxLen(a, b)
+	if n == 0 {
+		return nil
+	}
+
+	result := make(Vector, n)
+	for i := range a {
+		result[i] = alpha * a[i]
+	}
+	for i := range b {
+		result[i] += beta * b[i]
+	}
+	return result
+}
+
+// DualVector is a vector of dual numbers, with the same operations as Vector
+// so a function written once on it gives both the value and the derivatives
+type DualVector []Dual
+
+// Variables returns a dual vector of the point, where every component is an
+// input that the derivatives are taken with respect to
+func Variables(at Vector) DualVector {
+	result := make(DualVector, len(at))
+	for i, v := range at {
+		result[i] = Dual{v, make(Vector, len(at))}
+		result[i].Derivative[i] = 1
+	}
+	return result
+}
+
+// Constants returns a dual vector of the point with zero derivatives
+func Constants(v Vector) DualVector {
+	result := make(DualVector, len(v))
+	for i := range v {
+		result[i] = Constant(v[i])
+	}
+	return result
+}
+
+// Value returns the values of the dual vector
+func (a DualVector) Value() Vector {
+	result := make(Vector, len(a))
+	for i := range a {
+		result[i] = a[i].Value
+	}
+	return result
+}
+
+// Jacobian returns the derivatives of the dual vector as a list of rows, one
+// for each component
+func (a DualVector) Jacobian() []Vector {
+	n := 0
+	for i := range a {
+		if len(a[i].Derivative) > n {
+			n = len(a[i].Derivative)
+		}
+	}
+
+	result := zeros(len(a), n)
+	for i := range a {
+		copy(result[i], a[i].Derivative)
+	}
+	return result
+}
+
+// Clone a dual vector
+func (a DualVector) Clone() DualVector {
+	result := make(DualVector, len(a))
+	for i := range a {
+		result[i] = Dual{a[i].Value, clone(a[i].Derivative)}
+	}
+	return result
+}
+
+// Add returns the sum of two dual vectors
+func (a DualVector) Add(b DualVector) DualVector {
+	result := make(DualVector, len(a))
+	for i := range a {
+		result[i] = a[i].Add(b.component(i))
+	}
+	return result
+}
+
+// Sub returns the difference of two dual vectors
+func (a DualVector) Sub(b DualVector) DualVector {
+	result := make(DualVector, len(a))
+	for i := range a {
+		result[i] = a[i].Sub(b.component(i))
+	}
+	return result
+}
+
+// Invert returns the dual vector pointing in the opposite direction
+func (a DualVector) Invert() DualVector {
+	return a.Scale(Constant(-1))
+}
+
+// Scale returns the dual vector scaled by a dual number
+func (a DualVector) Scale(size Dual) DualVector {
+	result := make(DualVector, len(a))
+	for i := range a {
+		result[i] = a[i].Mul(size)
+	}
+	return result
+}
+
+// Dot product of two dual vectors
+func (a DualVector) Dot(b DualVector) Dual {
+	var result Dual
+	for i := 0; i < len(a) && i < len(b); i++ {
+		result = result.Add(a[i].Mul(b[i]))
+	}
+	return result
+}
+
+// Magnitude of a dual vector
+func (a DualVector) Magnitude() Dual {
+	return a.Dot(a).Sqrt()
+}
+
+// Unit returns a direction dual vector with the length of one, a vector close
+// to zero is returned unchanged like Vector.Unit
+func (a DualVector) Unit() DualVector {
+	l := a.Magnitude()
+	if l.Value < 1e-8 {
+		return a.Clone()
+	}
+
+	return a.Scale(Constant(1).Div(l))
+}
+
+// Cross product of two 3-dimensional dual vectors, it returns
+// ErrNot3Dimensional for any other dimension
+func (a DualVector) Cross(b DualVector) (DualVector, error) {
+	if len(a) != 3 || len(b) != 3 {
+		return nil, ErrNot3Dimensional
+	}
+
+	return DualVector{
+		a[y].Mul(b[z]).Sub(b[y].Mul(a[z])),
+		a[z].Mul(b[x]).Sub(b[z].Mul(a[x])),
+		a[x].Mul(b[y]).Sub(b[x].Mul(a[y])),
+	}, nil
+}
+
+// Rotate returns the dual vector rotated by an angle around an axis with
+// Rodrigues' rotation formula, which defaults to the Z axis. Like Vector.Rotate
+// a vector with more than 3-dimensions is cut to 3-dimensions, and a vector
+// with less than 3-dimensions rotated around the Z axis keeps its dimension.
+func (a DualVector) Rotate(angle Dual, axis ...DualVector) DualVector {
+	as := Constants(Z)
+	if len(axis) > 0 {
+		as = axis[0]
+	}
+
+	if len(a) == 0 {
+		return DualVector{}
+	}
+
+	v, u := a.resize(3), as.resize(3).Unit()
+	c, _ := u.Cross(v)
+	sin, cos := angle.Sin(), angle.Cos()
+
+	result := v.Scale(cos).Add(c.Scale(sin)).Add(u.Scale(u.Dot(v).Mul(Constant(1).Sub(cos))))
+
+	if len(a) < 3 && equal(as.Value(), Z) {
+		return result[:2]
+	}
+
+	return result
+}
+
+// X returns the first component, or a zero constant if it does not exist
+func (a DualVector) X() Dual {
+	return a.component(x)
+}
+
+// Y returns the second component, or a zero constant if it does not exist
+func (a DualVector) Y() Dual {
+	return a.component(y)
+}
+
+// Z returns the third component, or a zero constant if it does not exist
+func (a DualVector) Z() Dual {
+	return a.component(z)
+}
+
+func (a DualVector) component(i int) Dual {
+	if i < len(a) {
+		return a[i]
+	}
+	return Dual{}
+}
+
+func (a DualVector) resize(dim int) DualVector {
+	result := make(DualVector, dim)
+	copy(result, a)
+	return result
+}
+
+// DualFunc is a scalar function written on dual vectors, which gives its value
+// and its gradient from the same code
+type DualFunc func(DualVector) Dual
+
+// Value returns the value of the function at the point
+func (f DualFunc) Value(at Vector) float64 {
+	return f(Constants(at)).Value
+}
+
+// Gradient returns the value and the exact gradient of the function at the
+// point with forward-mode automatic differentiation
+func (f DualFunc) Gradient(at Vector) (float64, Vector) {
+	result := f(Variables(at))
+	return result.Value, resize(result.Derivative, len(at))
+}
+
+// Jacobian returns the value and the exact Jacobian, as a list of rows, of a
+// function from vectors to vectors written on dual vectors
+func Jacobian(f func(DualVector) DualVector, at Vector) (Vector, []Vector) {
+	result := f(Variables(at))
+	jacobian := result.Jacobian()
+	for i := range jacobian {
+		jacobian[i] = resize(jacobian[i], len(at))
+	}
+	return result.Value(), jacobian
+}
+
+// Gradient returns the gradient of a function at the point with central finite
+// differences. It works for any function, but is approximate and needs two
+// evaluations per dimension, so it is mostly useful to cross check the exact
+// gradient of a DualFunc, as in Gradient(f.Value, at).
+func Gradient(f func(Vector) float64, at Vector) Vector {
+	result := make(Vector, len(at))
+	point := clone(at)
+
+	for i := range at {
+		// the cube root of the machine epsilon balances the truncation error
+		// of the central difference against the rounding error
+		h := 6e-6 * math.Max(1, math.Abs(at[i]))
+
+		point[i] = at[i] + h
+		forward := f(point)
+		point[i] = at[i] - h
+		backward := f(point)
+		point[i] = at[i]
+
+		result[i] = (forward - backward) / (2 * h)
+	}
+
+	return result
+}
@@ -0,0 +1,134 @@
+package vector_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func TestDual(t *testing.T) {
+	// f(a, b) = sin(a) * b / sqrt(b) at a = 0.5, b = 4
+	v := vector.Variables(vec{0.5, 4})
+	f := v[0].Sin().Mul(v[1]).Div(v[1].Sqrt())
+
+	expected := vec{math.Cos(0.5) * 2, math.Sin(0.5) / 4}
+	if math.Abs(f.Value-math.Sin(0.5)*2) > 1e-12 || !f.Derivative.Equal(expected) {
+		t.Errorf("expected %v with derivative %v, got %v", math.Sin(0.5)*2, expected, f)
+	}
+
+	c := vector.Constant(3).Add(vector.Constant(2).Pow(3))
+	if c.Value != 11 || c.Derivative != nil {
+		t.Errorf("expected a constant 11, got %v", c)
+	}
+
+	if d := v[0].Sub(v[1]).Scale(2).Cos(); !d.Derivative.Equal(vec{-2 * math.Sin(-7), 2 * math.Sin(-7)}) {
+		t.Errorf("unexpected derivative %v", d.Derivative)
+	}
+}
+
+func TestDualVectorMatchesVector(t *testing.T) {
+	a, b := vec{1, -2, 0.5}, vec{0.3, 4, -1}
+	da, db := vector.Variables(a), vector.Constants(b)
+
+	if !da.Add(db).Value().Equal(a.Add(b)) || !da.Sub(db).Value().Equal(a.Sub(b)) {
+		t.Error("expected Add and Sub to match Vector")
+	}
+
+	if !da.Invert().Value().Equal(a.Invert()) || !da.Scale(vector.Constant(3)).Value().Equal(a.Scale(3)) {
+		t.Error("expected Invert and Scale to match Vector")
+	}
+
+	if da.Dot(db).Value != a.Dot(b) || da.Magnitude().Value != a.Magnitude() {
+		t.Error("expected Dot and Magnitude to match Vector")
+	}
+
+	if !da.Unit().Value().Equal(a.Unit()) {
+		t.Error("expected Unit to match Vector")
+	}
+
+	cross, _ := a.Cross(b)
+	if dc, err := da.Cross(db); err != nil || !dc.Value().Equal(cross) {
+		t.Errorf("expected Cross to match Vector, got %v", dc.Value())
+	}
+
+	if _, err := vector.Variables(vec{1, 2}).Cross(db); err != vector.ErrNot3Dimensional {
+		t.Errorf("expected ErrNot3Dimensional, got %v", err)
+	}
+
+	for _, axis := range []vec{vector.X, vector.Y, vector.Z, {1, 2, 3}} {
+		if r := da.Rotate(vector.Constant(0.7), vector.Constants(axis)); !r.Value().Equal(a.Rotate(0.7, axis)) {
+			t.Errorf("expected Rotate around %v to match Vector, got %v", axis, r.Value())
+		}
+	}
+
+	if r := vector.Variables(vec{1, 0}).Rotate(vector.Constant(math.Pi / 2)); len(r) != 2 || !r.Value().Equal(vec{0, 1}) {
+		t.Errorf("expected a 2-dimensional rotation, got %v", r.Value())
+	}
+
+	if da.X().Value != a.X() || da.Y().Value != a.Y() || da.Z().Value != a.Z() || vector.Variables(vec{1}).Z().Value != 0 {
+		t.Error("expected the getters to match Vector")
+	}
+}
+
+func TestDualFuncGradient(t *testing.T) {
+	w := vec{0.4, -1, 2}
+	axis := vector.Constants(vec{1, 2, 3})
+
+	// a function built from the whole surface of the dual vector
+	f := vector.DualFunc(func(v vector.DualVector) vector.Dual {
+		r := v.Unit().Rotate(v.X(), axis)
+		c, _ := v.Cross(vector.Constants(w))
+		return r.Dot(vector.Constants(w)).Add(c.Magnitude()).Mul(v.Z())
+	})
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		at := vec{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}
+		value, gradient := f.Gradient(at)
+
+		if value != f.Value(at) {
+			t.Fatalf("expected the value %v, got %v", f.Value(at), value)
+		}
+
+		numeric := vector.Gradient(f.Value, at)
+		if gradient.Sub(numeric).Magnitude() > 1e-6*(1+numeric.Magnitude()) {
+			t.Fatalf("gradient at %v is %v, finite differences give %v", at, gradient, numeric)
+		}
+	}
+}
+
+func TestGradient(t *testing.T) {
+	squared := func(v vec) float64 { return v.Dot(v) }
+
+	at := vec{1, -2, 3}
+	if g := vector.Gradient(squared, at); g.Sub(at.Scale(2)).Magnitude() > 1e-8 {
+		t.Errorf("expected %v, got %v", at.Scale(2), g)
+	}
+
+	// a constant function gets a gradient as long as the point
+	f := vector.DualFunc(func(v vector.DualVector) vector.Dual { return vector.Constant(1) })
+	if _, g := f.Gradient(at); !g.Equal(vec{0, 0, 0}) {
+		t.Errorf("expected a zero gradient, got %v", g)
+	}
+}
+
+func TestJacobian(t *testing.T) {
+	at := vec{3, 4}
+	value, jacobian := vector.Jacobian(func(v vector.DualVector) vector.DualVector {
+		return v.Unit()
+	}, at)
+
+	if !value.Equal(vec{0.6, 0.8}) {
+		t.Errorf("expected the unit vector, got %v", value)
+	}
+
+	// the derivative of the unit vector is (I - u uᵀ) / |v|
+	expected := []vec{{0.64 / 5, -0.48 / 5}, {-0.48 / 5, 0.36 / 5}}
+	for i := range expected {
+		if !jacobian[i].Equal(expected[i]) {
+			t.Errorf("expected the jacobian %v, got %v", expected, jacobian)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package vector
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// maxJacobiSweeps is the upper bound of full sweeps the Jacobi solvers
+	// will do before giving up on convergence
+	maxJacobiSweeps = 100
+)
+
+// Eigen computes the eigenvalues and eigenvectors of a symmetric matrix
+// using the cyclic Jacobi method. The matrix is given as a list of rows.
+//
+// The eigenvalues are sorted in descending order and the eigenvector at
+// index i belongs to the eigenvalue at index i. The eigenvectors all have
+// the length of one.
+func Eigen(m []Vector) (Vector, []Vector, error) {
+	n := len(m)
+
+	for i := range m {
+		if len(m[i]) != n {
+			return nil, nil, ErrNotSquareMatrix
+		}
+	}
+
+	// the thresholds are relative to the squared Frobenius norm, which the
+	// rotations keep, so the result does not depend on the scale of the matrix
+	norm := frobenius(m)
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if math.Abs(m[i][j]-m[j][i]) > 1e-8*math.Sqrt(norm) {
+				return nil, nil, ErrNotSymmetricMatrix
+			}
+		}
+	}
+
+	a, v := cloneMatrix(m), identity(n)
+
+	converged := false
+	for sweep := 0; sweep < maxJacobiSweeps; sweep++ {
+		if offDiagonal(a) <= 1e-22*norm {
+			converged = true
+			break
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				jacobiRotate(a, v, p, q)
+			}
+		}
+	}
+
+	if !converged && offDiagonal(a) > 1e-16*norm {
+		return nil, nil, ErrNoConvergence
+	}
+
+	values, vectors := make(Vector, n), make([]Vector, n)
+	for i := 0; i < n; i++ {
+		values[i] = a[i][i]
+		vectors[i] = make(Vector, n)
+		for j := 0; j < n; j++ {
+			vectors[i][j] = v[j][i]
+		}
+	}
+
+	sortEigen(values, vectors)
+
+	return values, vectors, nil
+}
+
+// SVD computes the singular value decomposition of a matrix with the one-sided
+// Jacobi method. The matrix is given as a list of rows and does not need to be
+// square.
+//
+// For a m×n matrix and k = min(m, n) it returns k left singular vectors of
+// m-dimensions, k singular values in descending order and k right singular
+// vectors of n-dimensions, such that the matrix equals the sum of
+// s[i] * u[i] * v[i]ᵀ.
+func SVD(m []Vector) ([]Vector, Vector, []Vector, error) {
+	rows := len(m)
+
+	if rows == 0 {
+		return []Vector{}, Vector{}, []Vector{}, nil
+	}
+
+	cols := len(m[0])
+	for i := range m {
+		if len(m[i]) != cols {
+			return nil, nil, nil, ErrNotSameDimensions
+		}
+	}
+
+	if rows < cols {
+		v, s, u, err := SVD(transpose(m))
+		return u, s, v, err
+	}
+
+	// the columns of the matrix are stored as vectors to make the rotations
+	// between the column pairs cheap
+	a, v := transpose(m), identity(cols)
+
+	converged := false
+	for sweep := 0; sweep < maxJacobiSweeps && !converged; sweep++ {
+		converged = true
+
+		for p := 0; p < cols-1; p++ {
+			for q := p + 1; q < cols; q++ {
+				alpha, beta, gamma := dot(a[p], a[p]), dot(a[q], a[q]), dot(a[p], a[q])
+
+				if math.Abs(gamma) <= 1e-15*math.Sqrt(alpha*beta) {
+					continue
+				}
+
+				converged = false
+
+				zeta := (beta - alpha) / (2 * gamma)
+				t := math.Copysign(1, zeta) / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				c := 1 / math.Sqrt(1+t*t)
+				s := c * t
+
+				givens(a[p], a[q], c, s)
+				givens(v[p], v[q], c, s)
+			}
+		}
+	}
+
+	if !converged {
+		return nil, nil, nil, ErrNoConvergence
+	}
+
+	s := make(Vector, cols)
+	for i := range a {
+		s[i] = magnitude(a[i])
+	}
+
+	sortEigen(s, a, v)
+
+	// the left singular vectors of singular values too small to divide by lie
+	// in the null space, and are completed to an orthonormal set instead
+	u := a
+	rank := 0
+	for rank < cols && s[rank] > 1e-15*s[0] {
+		scale(u[rank], 1/s[rank])
+		rank++
+	}
+
+	orthonormalize(u, rank)
+
+	return u, s, v, nil
+}
+
+// orthonormalize replaces the vectors from index k by unit vectors orthogonal
+// to all vectors before them, using Gram–Schmidt on the standard basis. The
+// vectors before k must already be orthonormal.
+func orthonormalize(m []Vector, k int) {
+	dim := 0
+	if len(m) > 0 {
+		dim = len(m[0])
+	}
+
+	for e := 0; e < dim && k < len(m); e++ {
+		w := make(Vector, dim)
+		w[e] = 1
+
+		// orthogonalizing twice keeps the result orthogonal to working precision
+		for pass := 0; pass < 2; pass++ {
+			for j := 0; j < k; j++ {
+				axpyUnitaryTo(w, -dot(m[j], w), m[j], w)
+			}
+		}
+
+		if l := magnitude(w); l > 0.5 {
+			m[k] = scale(w, 1/l)
+			k++
+		}
+	}
+}
+
+// PrincipalAxes returns the principal axes of a set of points sorted by the
+// variance of the points along them in descending order. It returns
+// ErrEmptyDataset for no points and ErrNoConvergence if the axes could not be
+// computed.
+func PrincipalAxes(points []Vector) ([]Vector, error) {
+	if len(points) == 0 {
+		return nil, ErrEmptyDataset
+	}
+
+	_, axes, err := Eigen(Covariance(points))
+	if err != nil {
+		return nil, err
+	}
+
+	return axes, nil
+}
+
+// FitPlane returns the least squares plane fitted to a set of points. The
+// plane is described by its unit normal and a point on the plane, which is the
+// centroid of the points. It returns the errors of PrincipalAxes.
+func FitPlane(points []Vector) (normal, point Vector, err error) {
+	axes, err := PrincipalAxes(points)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return axes[len(axes)-1], Mean(points), nil
+}
+
+func jacobiRotate(a, v []Vector, p, q int) {
+	if math.Abs(a[p][q]) < 1e-300 {
+		return
+	}
+
+	theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+	t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+	c := 1 / math.Sqrt(t*t+1)
+	s := t * c
+
+	for k := range a {
+		akp, akq := a[k][p], a[k][q]
+		a[k][p], a[k][q] = c*akp-s*akq, s*akp+c*akq
+	}
+
+	for k := range a {
+		apk, aqk := a[p][k], a[q][k]
+		a[p][k], a[q][k] = c*apk-s*aqk, s*apk+c*aqk
+	}
+
+	for k := range v {
+		vkp, vkq := v[k][p], v[k][q]
+		v[k][p], v[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+	}
+}
+
+// givens rotates the pair of vectors a and b in place
+func givens(a, b Vector, c, s float64) {
+	for i := range a {
+		ai, bi := a[i], b[i]
+		a[i], b[i] = c*ai-s*bi, s*ai+c*bi
+	}
+}
+
+func offDiagonal(a []Vector) float64 {
+	var result float64
+	for i := range a {
+		for j := range a[i] {
+			if i != j {
+				result += a[i][j] * a[i][j]
+			}
+		}
+	}
+	return result
+}
+
+// frobenius returns the squared Frobenius norm of a matrix
+func frobenius(a []Vector) float64 {
+	var result float64
+	for i := range a {
+		for _, v := range a[i] {
+			result += v * v
+		}
+	}
+	return result
+}
+
+func zeros(rows, cols int) []Vector {
+	m := make([]Vector, rows)
+	for i := range m {
+		m[i] = make(Vector, cols)
+	}
+	return m
+}
+
+func identity(n int) []Vector {
+	m := zeros(n, n)
+	for i := range m {
+		m[i][i] = 1
+	}
+	return m
+}
+
+func cloneMatrix(m []Vector) []Vector {
+	c := make([]Vector, len(m))
+	for i := range m {
+		c[i] = clone(m[i])
+	}
+	return c
+}
+
+func transpose(m []Vector) []Vector {
+	if len(m) == 0 {
+		return []Vector{}
+	}
+
+	t := make([]Vector, len(m[0]))
+	for i := range t {
+		t[i] = make(Vector, len(m))
+		for j := range m {
+			t[i][j] = m[j][i]
+		}
+	}
+	return t
+}
+
+// sortEigen sorts the values in descending order and keeps the vector lists
+// in the same order as the values
+func sortEigen(values Vector, vectors ...[]Vector) {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] > values[order[j]]
+	})
+
+	sorted := clone(values)
+	for i, o := range order {
+		values[i] = sorted[o]
+	}
+
+	for _, list := range vectors {
+		c := make([]Vector, len(list))
+		copy(c, list)
+		for i, o := range order {
+			list[i] = c[o]
+		}
+	}
+}
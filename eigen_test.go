@@ -0,0 +1,225 @@
+package vector_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func TestEigen(t *testing.T) {
+	m := []vec{
+		{4, 1, 2},
+		{1, 3, 0},
+		{2, 0, 5},
+	}
+
+	values, vectors, err := vector.Eigen(m)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 1; i < len(values); i++ {
+		if values[i] > values[i-1] {
+			t.Error("eigenvalues are not sorted in descending order")
+		}
+	}
+
+	for i, v := range vectors {
+		mv := make(vec, len(m))
+		for j := range m {
+			mv[j] = m[j].Dot(v)
+		}
+
+		if !mv.Equal(v.Scale(values[i])) {
+			t.Errorf("M * v != λ * v for eigenvalue %v", values[i])
+		}
+
+		if math.Abs(v.Magnitude()-1) > 1e-8 {
+			t.Error("eigenvector is not a unit vector")
+		}
+	}
+}
+
+func TestEigenInvalidMatrix(t *testing.T) {
+	if _, _, err := vector.Eigen([]vec{{1, 2}, {3}}); err != vector.ErrNotSquareMatrix {
+		t.Error("did not return ErrNotSquareMatrix for a non square matrix")
+	}
+
+	if _, _, err := vector.Eigen([]vec{{1, 2}, {3, 4}}); err != vector.ErrNotSymmetricMatrix {
+		t.Error("did not return ErrNotSymmetricMatrix for a non symmetric matrix")
+	}
+
+	if _, _, err := vector.Eigen([]vec{{1e-10, 1e-9}, {-1e-9, 1e-10}}); err != vector.ErrNotSymmetricMatrix {
+		t.Error("did not return ErrNotSymmetricMatrix for a small non symmetric matrix")
+	}
+}
+
+func TestSVD(t *testing.T) {
+	for _, m := range [][]vec{
+		{{3, 2, 2}, {2, 3, -2}},
+		{{1, 2}, {3, 4}, {5, 6}},
+		{{1, 1}, {1, 1}},
+	} {
+		u, s, v, err := vector.SVD(m)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := range m {
+			row := make(vec, len(m[i]))
+			for k := range s {
+				vector.In(row).Add(v[k].Scale(s[k] * u[k][i]))
+			}
+
+			if !row.Equal(m[i]) {
+				t.Errorf("U * S * Vᵀ does not reconstruct row %v, got %v", m[i], row)
+			}
+		}
+
+		for i := 1; i < len(s); i++ {
+			if s[i] > s[i-1] {
+				t.Error("singular values are not sorted in descending order")
+			}
+		}
+	}
+}
+
+func orthonormal(m []vec) bool {
+	for i := range m {
+		for j := range m {
+			expected := 0.
+			if i == j {
+				expected = 1
+			}
+
+			if math.Abs(m[i].Dot(m[j])-expected) > 1e-10 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestSVDOrthonormal(t *testing.T) {
+	for _, m := range [][]vec{
+		{{3, 2, 2}, {2, 3, -2}},
+		{{1, 2}, {3, 4}, {5, 6}},
+		{{1, 1}, {1, 1}},
+		{{1e-9, 0}, {0, 1e-9}},
+		{{1, 2, 3}, {0, 0, 0}},
+		{{1, 2, 3}, {2, 4, 6}, {0, 0, 0}},
+		{{0, 0}, {0, 0}},
+	} {
+		u, s, v, err := vector.SVD(m)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !orthonormal(u) || !orthonormal(v) {
+			t.Errorf("expected UᵀU = I and VᵀV = I for %v, got U = %v, V = %v", m, u, v)
+		}
+
+		for i := range m {
+			row := make(vec, len(m[i]))
+			for k := range s {
+				vector.In(row).Add(v[k].Scale(s[k] * u[k][i]))
+			}
+
+			if !row.Equal(m[i]) {
+				t.Errorf("U * S * Vᵀ does not reconstruct row %v, got %v", m[i], row)
+			}
+		}
+	}
+}
+
+func TestFitPlane(t *testing.T) {
+	points := []vec{
+		{0, 0, 1}, {1, 0, 1}, {0, 1, 1}, {1, 1, 1}, {0.5, 0.25, 1},
+	}
+
+	normal, point, err := vector.FitPlane(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if math.Abs(math.Abs(normal.Z())-1) > 1e-8 {
+		t.Errorf("expected normal to be parallel to the z axis, got %v", normal)
+	}
+
+	if math.Abs(point.Z()-1) > 1e-8 {
+		t.Errorf("expected the point to lie in the plane z = 1, got %v", point)
+	}
+}
+
+func TestPrincipalAxes(t *testing.T) {
+	points := []vec{{-2, -2}, {-1, -1}, {1, 1}, {2, 2}, {0.1, -0.1}}
+
+	axes, err := vector.PrincipalAxes(points)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(axes) != 2 {
+		t.Fatalf("expected 2 axes, got %v", len(axes))
+	}
+
+	if math.Abs(math.Abs(axes[0].Dot(vec{1, 1}.Unit()))-1) > 1e-8 {
+		t.Errorf("expected first principal axis along the diagonal, got %v", axes[0])
+	}
+}
+
+func TestEigenScale(t *testing.T) {
+	m := []vec{
+		{4, 1, 2},
+		{1, 3, 0},
+		{2, 0, 5},
+	}
+
+	expected, _, _ := vector.Eigen(m)
+
+	for _, size := range []float64{1e-9, 1e9} {
+		scaled := make([]vec, len(m))
+		for i := range m {
+			scaled[i] = m[i].Scale(size)
+		}
+
+		values, _, err := vector.Eigen(scaled)
+		if err != nil {
+			t.Fatalf("scaled by %v: %v", size, err)
+		}
+
+		if !values.Scale(1 / size).Equal(expected) {
+			t.Errorf("scaled by %v: expected %v, got %v", size, expected.Scale(size), values)
+		}
+	}
+
+	// meter scale data spread over a few thousand kilometers
+	points := make([]vec, 100)
+	for i := range points {
+		f := float64(i)
+		points[i] = vec{4e6 + 3e4*f, 2e6 - 1e4*f + 50*math.Sin(f), 1e6 + 7*math.Cos(f)}
+	}
+
+	p := vector.NewPCA(2)
+	if err := p.Fit(points); err != nil {
+		t.Fatal(err)
+	}
+
+	if axis := p.Components()[0]; math.Abs(math.Abs(axis.Dot(vec{3, -1, 0}.Unit()))-1) > 1e-6 {
+		t.Errorf("expected the first component along {3, -1, 0}, got %v", axis)
+	}
+
+	if axes, err := vector.PrincipalAxes(points); err != nil || len(axes) != 3 {
+		t.Errorf("expected 3 principal axes, got %v (%v)", axes, err)
+	}
+}
+
+func TestFitPlaneEmpty(t *testing.T) {
+	if _, _, err := vector.FitPlane(nil); err != vector.ErrEmptyDataset {
+		t.Errorf("expected ErrEmptyDataset, got %v", err)
+	}
+}
@@ -13,4 +13,36 @@ var (
 	// ErrNotValidSwizzleIndex is an error that is returned when swizzling a vector and passing
 	// an index that lies outside of the length of the vector
 	ErrNotValidSwizzleIndex = errors.New("index for swizzling is not valid for the given vector")
+
+	// ErrNotSquareMatrix is an error that is returned when a matrix, given as a
+	// list of row vectors, needs to have the same amount of rows and columns
+	ErrNotSquareMatrix = errors.New("the matrix provided is not square")
+	// ErrNotSymmetricMatrix is an error that is returned when a matrix needs to
+	// be equal to its own transpose
+	ErrNotSymmetricMatrix = errors.New("the matrix provided is not symmetric")
+	// ErrNoConvergence is an error that is returned when an iterative method
+	// did not converge within its maximum number of iterations
+	ErrNoConvergence = errors.New("the iterative method did not converge")
+	// ErrNotSameLength is an error that is returned when two lists, like a set
+	// of vectors and their weights, need to have the same length
+	ErrNotSameLength = errors.New("the two lists provided aren't the same length")
+	// ErrEmptyDataset is an error that is returned when a model is fitted on a
+	// dataset without any vectors
+	ErrEmptyDataset = errors.New("the dataset provided is empty")
+	// ErrNotValidClusterCount is an error that is returned when the number of
+	// clusters asked for is not between 1 and the size of the dataset
+	ErrNotValidClusterCount = errors.New("the number of clusters is not valid for the given dataset")
+	// ErrNotPositiveDefinite is an error that is returned when a symmetric
+	// matrix, like a covariance matrix, needs to be positive definite
+	ErrNotPositiveDefinite = errors.New("the matrix provided is not positive definite")
+	// ErrNotValidDimension is an error that is returned when a dimension is
+	// outside of the range a function supports
+	ErrNotValidDimension = errors.New("the dimension is not supported")
+	// ErrNotValidIndex is an error that is returned when an index lies outside
+	// of the dimension of a vector
+	ErrNotValidIndex = errors.New("index is not valid for the given vector")
+	// ErrAntipodalPoints is an error that is returned when the great circle
+	// between two positions is asked for, but they lie on opposite sides of the
+	// earth where every great circle through one passes through the other
+	ErrAntipodalPoints = errors.New("the positions are antipodal and have no unique great circle")
 )
@@ -117,3 +117,31 @@ func ExampleVector_Invert() {
 	)
 	// Output: [-19 -0 -3]
 }
+
+func ExampleVector_Mul() {
+	fmt.Println(
+		vec{1, 2, 3}.Mul(vec{4, 5, 6}),
+	)
+	// Output: [4 10 18]
+}
+
+func ExampleVector_Map() {
+	fmt.Println(
+		vec{1, 4, 9}.Map(math.Sqrt),
+	)
+	// Output: [1 2 3]
+}
+
+func ExampleVector_Reduce() {
+	fmt.Println(
+		vec{3, 1, 2}.Reduce(math.Max, math.Inf(-1)),
+	)
+	// Output: 3
+}
+
+func ExampleVector_SwizzleStr() {
+	fmt.Println(
+		vec{1, 2, 3}.SwizzleStr("zyxx"),
+	)
+	// Output: [3 2 1 1] <nil>
+}
@@ -0,0 +1,175 @@
+package vector
+
+// exprBlock is the number of components an expression evaluates at a time,
+// small enough for a block of the result to stay in the cache while every step
+// of the expression runs over it
+const exprBlock = 512
+
+type exprOp int
+
+const (
+	// acc += alpha * v
+	exprAdd exprOp = iota
+	// acc *= alpha
+	exprScale
+	// acc = alpha*acc + v, a scale followed by an addition
+	exprScaleAdd
+	// acc += alpha
+	exprAddScalar
+	exprMul
+	exprDiv
+	exprMap
+)
+
+type exprStep struct {
+	op    exprOp
+	v     Vector
+	alpha float64
+	f     func(float64) float64
+}
+
+// Expression is a lazy chain of elementwise operations on a vector, which is
+// only computed by Eval. Instead of cloning the vector at every step like the
+// methods of Vector, all steps are fused into a single pass over the data,
+// where a scale followed by an addition runs as one axpy kernel.
+//
+// Expressions are values, so a partial expression can be reused as the start
+// of several others.
+type Expression struct {
+	a     Vector
+	steps []exprStep
+}
+
+// Expr starts a lazy expression on the vector a
+//
+//	vector.Expr(a).Add(b).Scale(2).Sub(c).Eval(dst)
+func Expr(a Vector) Expression {
+	return Expression{a: a}
+}
+
+// Add b to the expression
+func (e Expression) Add(b Vector) Expression {
+	return e.AddScaled(b, 1)
+}
+
+// Sub subtracts b from the expression
+func (e Expression) Sub(b Vector) Expression {
+	return e.AddScaled(b, -1)
+}
+
+// AddScaled adds b multiplied by alpha to the expression
+func (e Expression) AddScaled(b Vector, alpha float64) Expression {
+	if last := len(e.steps) - 1; alpha == 1 && last >= 0 && e.steps[last].op == exprScale {
+		return e.replace(exprStep{op: exprScaleAdd, v: b, alpha: e.steps[last].alpha})
+	}
+
+	return e.append(exprStep{op: exprAdd, v: b, alpha: alpha})
+}
+
+// Scale multiplies the expression by size
+func (e Expression) Scale(size float64) Expression {
+	if last := len(e.steps) - 1; last >= 0 && e.steps[last].op == exprScale {
+		return e.replace(exprStep{op: exprScale, alpha: e.steps[last].alpha * size})
+	}
+
+	return e.append(exprStep{op: exprScale, alpha: size})
+}
+
+// AddScalar adds v to every component of the expression
+func (e Expression) AddScalar(v float64) Expression {
+	return e.append(exprStep{op: exprAddScalar, alpha: v})
+}
+
+// Mul multiplies the expression componentwise with b
+func (e Expression) Mul(b Vector) Expression {
+	return e.append(exprStep{op: exprMul, v: b})
+}
+
+// Div divides the expression componentwise by b
+func (e Expression) Div(b Vector) Expression {
+	return e.append(exprStep{op: exprDiv, v: b})
+}
+
+// Map applies f to every component of the expression
+func (e Expression) Map(f func(float64) float64) Expression {
+	return e.append(exprStep{op: exprMap, f: f})
+}
+
+// Eval computes the expression into dst and returns it. Like the ...To
+// functions dst is resized to the dimension of the result within its capacity,
+// so the returned vector must be used instead of dst, and a nil dst returns a
+// new vector. The destination may be the first vector of the expression, but
+// it must not share memory with any other operand.
+func (e Expression) Eval(dst MutableVector) MutableVector {
+	dst = destination(dst, len(e.a))
+
+	for lo := 0; lo < len(e.a); lo += exprBlock {
+		hi := lo + exprBlock
+		if hi > len(e.a) {
+			hi = len(e.a)
+		}
+
+		acc := dst[lo:hi]
+		copy(acc, e.a[lo:hi])
+
+		for _, s := range e.steps {
+			s.eval(acc, block(s.v, lo, hi))
+		}
+	}
+
+	return dst
+}
+
+func (s exprStep) eval(acc, v []float64) {
+	n := len(v)
+
+	switch s.op {
+	case exprAdd:
+		axpyUnitaryTo(acc[:n], s.alpha, v, acc[:n])
+	case exprScale:
+		scalUnitaryTo(acc, s.alpha, acc)
+	case exprScaleAdd:
+		axpyUnitaryTo(acc[:n], s.alpha, acc[:n], v)
+		scalUnitaryTo(acc[n:], s.alpha, acc[n:])
+	case exprAddScalar:
+		for i := range acc {
+			acc[i] += s.alpha
+		}
+	case exprMul:
+		for i := range v {
+			acc[i] *= v[i]
+		}
+	case exprDiv:
+		for i := range v {
+			acc[i] /= v[i]
+		}
+	case exprMap:
+		for i := range acc {
+			acc[i] = s.f(acc[i])
+		}
+	}
+}
+
+// append returns a copy of the expression with one more step, without sharing
+// the steps with the expression it was built from
+func (e Expression) append(s exprStep) Expression {
+	n := len(e.steps)
+	return Expression{a: e.a, steps: append(e.steps[:n:n], s)}
+}
+
+// replace returns a copy of the expression with its last step replaced
+func (e Expression) replace(s exprStep) Expression {
+	n := len(e.steps) - 1
+	return Expression{a: e.a, steps: append(e.steps[:n:n], s)}
+}
+
+// block returns the components of v between lo and hi that exist
+func block(v []float64, lo, hi int) []float64 {
+	if lo >= len(v) {
+		return nil
+	}
+	if hi > len(v) {
+		hi = len(v)
+	}
+	return v[lo:hi]
+}
@@ -0,0 +1,106 @@
+package vector_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func TestExpression(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	// sizes around the block size check the steps across block boundaries
+	for _, n := range []int{0, 1, 3, 17, 512, 1000, 1537} {
+		a, b, c := randomSignal(r, n), randomSignal(r, n), randomSignal(r, n)
+		short := randomSignal(r, n/3)
+
+		cases := []struct {
+			name     string
+			result   vector.MutableVector
+			expected vec
+		}{
+			{"identity", vector.Expr(a).Eval(nil), a},
+			{"chain", vector.Expr(a).Add(b).Scale(2).Sub(c).Eval(nil), a.Add(b).Scale(2).Sub(c)},
+			{"scale then add", vector.Expr(a).Scale(3).Scale(0.5).Add(b).Eval(nil), a.Scale(1.5).Add(b)},
+			{"scale then short add", vector.Expr(a).Scale(3).Add(short).Eval(nil), a.Scale(3).Add(short)},
+			{"short operands", vector.Expr(a).Sub(short).Mul(short).Div(short).Eval(nil), a.Sub(short).Mul(short).Div(short)},
+			{"scaled addition", vector.Expr(a).AddScaled(b, -0.25).Eval(nil), a.Add(b.Scale(-0.25))},
+			{"scalar", vector.Expr(a).AddScalar(1).Scale(2).AddScalar(-3).Eval(nil), a.Map(func(v float64) float64 { return 2*(v+1) - 3 })},
+			{"map", vector.Expr(a).Mul(b).Map(math.Abs).Eval(nil), a.Mul(b).Abs()},
+		}
+
+		for _, c := range cases {
+			if len(c.result) != len(c.expected) || !vec(c.result).Equal(c.expected) {
+				t.Errorf("%v of dimension %v does not match the chained methods", c.name, n)
+			}
+		}
+	}
+}
+
+func TestExpressionReuse(t *testing.T) {
+	a, b := vec{1, 2, 3}, vec{1, 1, 1}
+
+	scaled := vector.Expr(a).Scale(2)
+	added, subtracted := scaled.Add(b), scaled.Sub(b)
+	rescaled := scaled.Scale(5)
+
+	if result := added.Eval(nil); !vec(result).Equal(vec{3, 5, 7}) {
+		t.Errorf("expected {3, 5, 7}, got %v", result)
+	}
+
+	if result := subtracted.Eval(nil); !vec(result).Equal(vec{1, 3, 5}) {
+		t.Errorf("expected {1, 3, 5}, got %v", result)
+	}
+
+	if result := rescaled.Eval(nil); !vec(result).Equal(vec{10, 20, 30}) {
+		t.Errorf("expected {10, 20, 30}, got %v", result)
+	}
+
+	if result := scaled.Eval(nil); !vec(result).Equal(vec{2, 4, 6}) {
+		t.Errorf("expected the partial expression to be unchanged, got %v", result)
+	}
+
+	// the expression can be evaluated into its first vector
+	v := vector.MutableVector{1, 2, 3}
+	if vector.Expr(vec(v)).Add(b).Scale(2).Eval(v); !vec(v).Equal(vec{4, 6, 8}) {
+		t.Errorf("expected {4, 6, 8}, got %v", v)
+	}
+}
+
+func TestExpressionAllocations(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a, b, c := randomSignal(r, 2000), randomSignal(r, 2000), randomSignal(r, 2000)
+	e := vector.Expr(a).Add(b).Scale(2).Sub(c).AddScalar(1)
+	dst := make(vector.MutableVector, 2000)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		e.Eval(dst)
+	})
+
+	if allocs != 0 {
+		t.Errorf("expected no allocations when evaluating into a buffer, got %v", allocs)
+	}
+}
+
+func BenchmarkExpression(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	x, y, z := randomSignal(r, 100000), randomSignal(r, 100000), randomSignal(r, 100000)
+	dst := make(vector.MutableVector, 100000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		vector.Expr(x).Add(y).Scale(2).Sub(z).Eval(dst)
+	}
+}
+
+func BenchmarkChainedMethods(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	x, y, z := randomSignal(r, 100000), randomSignal(r, 100000), randomSignal(r, 100000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Add(y).Scale(2).Sub(z)
+	}
+}
@@ -0,0 +1,444 @@
+package vector
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// maxRadix is the largest prime factor of a size that is transformed directly,
+// sizes with larger prime factors use Bluestein's algorithm instead
+const maxRadix = 31
+
+// FFT is a plan for the discrete Fourier transform of a fixed size, which
+// precomputes the factors of the size and the twiddle factors so they are
+// reused for every transform of that size. Sizes made of small primes use a
+// mixed-radix Cooley-Tukey algorithm, other sizes Bluestein's algorithm.
+//
+// All transforms write into a caller supplied buffer, which is only allocated
+// when it is too small, so repeated transforms do not allocate. A plan holds
+// scratch space and is not safe for concurrent use, make one plan per
+// goroutine instead.
+type FFT struct {
+	n         int
+	factors   []int
+	twiddles  []complex128
+	work      []complex128
+	butterfly []complex128
+	bluestein *bluestein
+}
+
+// bluestein computes a transform of any size as a convolution with a chirp,
+// done with a power of two transform
+type bluestein struct {
+	chirp  []complex128
+	kernel []complex128
+	plan   *FFT
+	work   []complex128
+}
+
+// NewFFT returns a plan for transforms of the given size
+func NewFFT(n int) *FFT {
+	if n < 0 {
+		n = 0
+	}
+
+	p := &FFT{n: n, work: make([]complex128, n)}
+	if n == 0 {
+		return p
+	}
+
+	// a size of one has no prime factors and is its own transform
+	p.factors = factorize(n)
+	largest := 1
+	if len(p.factors) > 0 {
+		largest = p.factors[len(p.factors)-1]
+	}
+
+	if largest > maxRadix {
+		p.bluestein = newBluestein(n)
+		return p
+	}
+
+	p.twiddles = make([]complex128, n)
+	for k := range p.twiddles {
+		p.twiddles[k] = cmplx.Rect(1, -2*math.Pi*float64(k)/float64(n))
+	}
+
+	p.butterfly = make([]complex128, largest)
+	return p
+}
+
+// Len returns the size of the transforms of the plan
+func (p *FFT) Len() int {
+	return p.n
+}
+
+// Forward writes the discrete Fourier transform of src into dst and returns it,
+// where dst is allocated if it has less capacity than the size of the plan. A
+// shorter src is padded with zeros and a longer one is cut, and src and dst
+// may be the same buffer.
+func (p *FFT) Forward(dst, src ComplexVector) ComplexVector {
+	dst = buffer(dst, p.n)
+	n := copy(p.work, src)
+	for i := n; i < p.n; i++ {
+		p.work[i] = 0
+	}
+
+	p.transform(dst)
+	return dst
+}
+
+// Inverse writes the inverse discrete Fourier transform of src into dst and
+// returns it, scaled by one over the size so it undoes Forward. Buffers are
+// handled like Forward.
+func (p *FFT) Inverse(dst, src ComplexVector) ComplexVector {
+	dst = buffer(dst, p.n)
+
+	// the inverse is the conjugate of the forward transform of the conjugate
+	for i := range p.work {
+		p.work[i] = 0
+		if i < len(src) {
+			p.work[i] = cmplx.Conj(src[i])
+		}
+	}
+
+	p.transform(dst)
+
+	s := 1 / float64(p.n)
+	for i := range dst {
+		dst[i] = complex(real(dst[i])*s, -imag(dst[i])*s)
+	}
+
+	return dst
+}
+
+// transform writes the transform of the work buffer into dst
+func (p *FFT) transform(dst ComplexVector) {
+	if p.n == 0 {
+		return
+	}
+
+	if p.bluestein != nil {
+		p.bluestein.transform(dst, p.work)
+		return
+	}
+
+	p.cooleyTukey(dst, p.work, 1, p.factors)
+}
+
+// cooleyTukey is the decimation in time step, which transforms the r
+// interleaved subsequences of src into the r consecutive parts of dst and
+// combines them with the butterflies of radix r
+func (p *FFT) cooleyTukey(dst, src []complex128, stride int, factors []int) {
+	n := len(dst)
+	if n == 1 {
+		dst[0] = src[0]
+		return
+	}
+
+	r := factors[0]
+	m := n / r
+	for q := 0; q < r; q++ {
+		p.cooleyTukey(dst[q*m:(q+1)*m], src[q*stride:], stride*r, factors[1:])
+	}
+
+	step := p.n / n
+
+	if r == 2 {
+		for k := 0; k < m; k++ {
+			t := dst[m+k] * p.twiddles[k*step]
+			dst[m+k] = dst[k] - t
+			dst[k] += t
+		}
+		return
+	}
+
+	t := p.butterfly[:r]
+	for k := 0; k < m; k++ {
+		for q := 0; q < r; q++ {
+			t[q] = dst[q*m+k] * p.twiddles[q*k*step]
+		}
+
+		for s := 0; s < r; s++ {
+			sum := t[0]
+			for q := 1; q < r; q++ {
+				sum += t[q] * p.twiddles[(q*s*(p.n/r))%p.n]
+			}
+			dst[s*m+k] = sum
+		}
+	}
+}
+
+func newBluestein(n int) *bluestein {
+	m := 1
+	for m < 2*n-1 {
+		m *= 2
+	}
+
+	b := &bluestein{
+		chirp:  make([]complex128, n),
+		kernel: make([]complex128, m),
+		plan:   NewFFT(m),
+		work:   make([]complex128, m),
+	}
+
+	// the chirp is e^(-πik²/n), with k² taken modulo 2n to keep the angle
+	// small and precise for large sizes
+	for k := range b.chirp {
+		k2 := (int64(k) * int64(k)) % (2 * int64(n))
+		b.chirp[k] = cmplx.Rect(1, -math.Pi*float64(k2)/float64(n))
+	}
+
+	b.kernel[0] = cmplx.Conj(b.chirp[0])
+	for k := 1; k < n; k++ {
+		b.kernel[k] = cmplx.Conj(b.chirp[k])
+		b.kernel[m-k] = b.kernel[k]
+	}
+	b.plan.Forward(b.kernel, b.kernel)
+
+	return b
+}
+
+func (b *bluestein) transform(dst, src []complex128) {
+	for k := range b.work {
+		b.work[k] = 0
+		if k < len(src) {
+			b.work[k] = src[k] * b.chirp[k]
+		}
+	}
+
+	b.plan.Forward(b.work, b.work)
+	for k := range b.work {
+		b.work[k] *= b.kernel[k]
+	}
+	b.plan.Inverse(b.work, b.work)
+
+	for k := range dst {
+		dst[k] = b.work[k] * b.chirp[k]
+	}
+}
+
+// factorize returns the prime factors of n in ascending order
+func factorize(n int) []int {
+	var factors []int
+	for f := 2; f*f <= n; f++ {
+		for n%f == 0 {
+			factors = append(factors, f)
+			n /= f
+		}
+	}
+
+	if n > 1 {
+		factors = append(factors, n)
+	}
+
+	return factors
+}
+
+// buffer returns the slice with the given length, reusing its memory when it
+// has the capacity for it
+func buffer(a ComplexVector, n int) ComplexVector {
+	if cap(a) >= n {
+		return a[:n]
+	}
+	return make(ComplexVector, n)
+}
+
+// RealFFT is a plan for the discrete Fourier transform of real signals of a
+// fixed size. The spectrum of a real signal is conjugate symmetric, so only
+// the first half of it is computed, with n/2+1 components. Even sizes are
+// transformed as a complex signal of half the size.
+//
+// Like FFT, transforms write into caller supplied buffers and a plan is not
+// safe for concurrent use.
+type RealFFT struct {
+	n        int
+	plan     *FFT
+	twiddles []complex128
+	work     ComplexVector
+	spectra  [2]ComplexVector
+}
+
+// NewRealFFT returns a plan for transforms of real signals of the given size
+func NewRealFFT(n int) *RealFFT {
+	if n < 0 {
+		n = 0
+	}
+
+	p := &RealFFT{n: n}
+	size := n
+	if n%2 == 0 {
+		size = n / 2
+		p.twiddles = make([]complex128, size+1)
+		for k := range p.twiddles {
+			p.twiddles[k] = cmplx.Rect(1, -2*math.Pi*float64(k)/float64(n))
+		}
+	}
+
+	p.plan = NewFFT(size)
+	p.work = make(ComplexVector, size)
+	return p
+}
+
+// Len returns the size of the signals of the plan
+func (p *RealFFT) Len() int {
+	return p.n
+}
+
+// Forward writes the first n/2+1 components of the spectrum of the real signal
+// into dst and returns it, where dst is allocated if it has less capacity. A
+// shorter signal is padded with zeros and a longer one is cut.
+func (p *RealFFT) Forward(dst ComplexVector, src Vector) ComplexVector {
+	if p.n == 0 {
+		return dst[:0]
+	}
+
+	dst = buffer(dst, p.n/2+1)
+
+	if p.n%2 == 1 {
+		for i := range p.work {
+			p.work[i] = complex(component(src, i), 0)
+		}
+		p.plan.Forward(p.work, p.work)
+		copy(dst, p.work)
+		return dst
+	}
+
+	// the even and odd samples are packed as the real and imaginary parts of a
+	// signal of half the size, and separated again using the symmetry of the
+	// spectra of real signals
+	half := p.n / 2
+	for k := range p.work {
+		p.work[k] = complex(component(src, 2*k), component(src, 2*k+1))
+	}
+	p.plan.Forward(p.work, p.work)
+
+	for k := half; k >= 0; k-- {
+		z, c := p.work[k%half], cmplx.Conj(p.work[(half-k)%half])
+		even, odd := (z+c)/2, (z-c)*complex(0, -0.5)
+		dst[k] = even + p.twiddles[k]*odd
+	}
+
+	return dst
+}
+
+// Inverse writes the real signal of the first n/2+1 components of a spectrum
+// into dst and returns it, where dst is allocated if it has less capacity. It
+// undoes Forward.
+func (p *RealFFT) Inverse(dst Vector, src ComplexVector) Vector {
+	if cap(dst) >= p.n {
+		dst = dst[:p.n]
+	} else {
+		dst = make(Vector, p.n)
+	}
+
+	if p.n == 0 {
+		return dst
+	}
+
+	spectrum := func(k int) complex128 {
+		if k < len(src) {
+			return src[k]
+		}
+		return 0
+	}
+
+	if p.n%2 == 1 {
+		for k := range p.work {
+			if k <= p.n/2 {
+				p.work[k] = spectrum(k)
+			} else {
+				p.work[k] = cmplx.Conj(spectrum(p.n - k))
+			}
+		}
+
+		p.plan.Inverse(p.work, p.work)
+		for i := range dst {
+			dst[i] = real(p.work[i])
+		}
+		return dst
+	}
+
+	half := p.n / 2
+	for k := range p.work {
+		x, c := spectrum(k), cmplx.Conj(spectrum(half-k))
+		even, odd := (x+c)/2, (x-c)/2*cmplx.Conj(p.twiddles[k])
+		p.work[k] = even + complex(0, 1)*odd
+	}
+	p.plan.Inverse(p.work, p.work)
+
+	for k, z := range p.work {
+		dst[2*k], dst[2*k+1] = real(z), imag(z)
+	}
+
+	return dst
+}
+
+// Convolve writes the circular convolution of two real signals of the size of
+// the plan into dst and returns it. When the size is at least
+// len(a) + len(b) - 1 its first components are the linear convolution.
+func (p *RealFFT) Convolve(dst, a, b Vector) Vector {
+	p.spectra[0] = p.Forward(p.spectra[0], a)
+	p.spectra[1] = p.Forward(p.spectra[1], b)
+
+	for k := range p.spectra[0] {
+		p.spectra[0][k] *= p.spectra[1][k]
+	}
+
+	return p.Inverse(dst, p.spectra[0])
+}
+
+// Correlate writes the circular cross-correlation of two real signals of the
+// size of the plan into dst and returns it, where component k is the sum of
+// a[i+k] * b[i] with the index i+k wrapping around the size
+func (p *RealFFT) Correlate(dst, a, b Vector) Vector {
+	p.spectra[0] = p.Forward(p.spectra[0], a)
+	p.spectra[1] = p.Forward(p.spectra[1], b)
+
+	for k := range p.spectra[0] {
+		p.spectra[0][k] *= cmplx.Conj(p.spectra[1][k])
+	}
+
+	return p.Inverse(dst, p.spectra[0])
+}
+
+// Convolve returns the linear convolution of two real signals, with the length
+// len(a) + len(b) - 1. It makes a new plan on every call, use RealFFT.Convolve
+// to reuse one.
+func Convolve(a, b Vector) Vector {
+	if len(a) == 0 || len(b) == 0 {
+		return Vector{}
+	}
+
+	size := len(a) + len(b) - 1
+	return NewRealFFT(nextPowerOfTwo(size)).Convolve(nil, a, b)[:size]
+}
+
+// Correlate returns the linear cross-correlation of two real signals, with the
+// length len(a) + len(b) - 1, where component i is the sum of a[j+lag] * b[j]
+// for the lag i - len(b) + 1. It makes a new plan on every call, use
+// RealFFT.Correlate to reuse one.
+func Correlate(a, b Vector) Vector {
+	if len(a) == 0 || len(b) == 0 {
+		return Vector{}
+	}
+
+	size := len(a) + len(b) - 1
+	n := nextPowerOfTwo(size)
+	circular := NewRealFFT(n).Correlate(nil, a, b)
+
+	// negative lags wrap around to the end of the circular correlation
+	result := make(Vector, size)
+	for i := range result {
+		result[i] = circular[(i-len(b)+1+n)%n]
+	}
+	return result
+}
+
+func nextPowerOfTwo(n int) int {
+	result := 1
+	for result < n {
+		result *= 2
+	}
+	return result
+}
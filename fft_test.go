@@ -0,0 +1,171 @@
+package vector_test
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+// dft is the direct O(n²) discrete Fourier transform
+func dft(x vector.ComplexVector) vector.ComplexVector {
+	n := len(x)
+	result := make(vector.ComplexVector, n)
+	for k := range result {
+		for j := range x {
+			result[k] += x[j] * cmplx.Rect(1, -2*math.Pi*float64(j*k%n)/float64(n))
+		}
+	}
+	return result
+}
+
+func closeComplex(a, b vector.ComplexVector, tolerance float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	return a.Sub(b).Magnitude() <= tolerance*(1+b.Magnitude())
+}
+
+func randomSignal(r *rand.Rand, n int) vec {
+	result := make(vec, n)
+	for i := range result {
+		result[i] = r.NormFloat64()
+	}
+	return result
+}
+
+func TestFFT(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	sizes := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 12, 15, 16, 30, 31, 37, 49, 64, 97, 100, 360, 1024, 1031}
+
+	for _, n := range sizes {
+		p := vector.NewFFT(n)
+		x := randomComplex(r, n)
+		expected := dft(x)
+
+		if got := p.Forward(nil, x); !closeComplex(got, expected, 1e-10) {
+			t.Errorf("transform of size %v differs from the direct transform", n)
+		}
+
+		if back := p.Inverse(nil, p.Forward(nil, x)); !closeComplex(back, x, 1e-10) {
+			t.Errorf("inverse of size %v does not undo the transform", n)
+		}
+
+		// the transform can be done in place
+		inPlace := x.Clone()
+		if p.Forward(inPlace, inPlace); !closeComplex(inPlace, expected, 1e-10) {
+			t.Errorf("in place transform of size %v differs", n)
+		}
+	}
+}
+
+func TestFFTPadding(t *testing.T) {
+	p := vector.NewFFT(8)
+	x := vector.ComplexVector{1, 2, 3}
+
+	padded := append(x.Clone(), make(vector.ComplexVector, 5)...)
+	if !closeComplex(p.Forward(nil, x), dft(padded), 1e-12) {
+		t.Error("expected a short signal to be padded with zeros")
+	}
+
+	if got := vector.NewFFT(0).Forward(nil, x); len(got) != 0 {
+		t.Errorf("expected an empty transform, got %v", got)
+	}
+}
+
+func TestRealFFT(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{1, 2, 3, 4, 5, 8, 9, 10, 64, 97, 100, 194} {
+		p := vector.NewRealFFT(n)
+		x := randomSignal(r, n)
+		full := dft(vector.Complex(x, nil))
+
+		half := p.Forward(nil, x)
+		if !closeComplex(half, full[:n/2+1], 1e-10) {
+			t.Errorf("real transform of size %v differs from the first half of the complex transform", n)
+		}
+
+		if back := p.Inverse(nil, half); back.Sub(x).Magnitude() > 1e-10*(1+x.Magnitude()) {
+			t.Errorf("inverse real transform of size %v does not undo the transform", n)
+		}
+	}
+}
+
+func TestConvolve(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a, b := randomSignal(r, 13), randomSignal(r, 6)
+
+	direct, lags := make(vec, len(a)+len(b)-1), make(vec, len(a)+len(b)-1)
+	for i := range a {
+		for j := range b {
+			direct[i+j] += a[i] * b[j]
+			lags[i-j+len(b)-1] += a[i] * b[j]
+		}
+	}
+
+	if c := vector.Convolve(a, b); len(c) != len(direct) || c.Sub(direct).Magnitude() > 1e-10 {
+		t.Errorf("expected the convolution %v, got %v", direct, c)
+	}
+
+	if c := vector.Correlate(a, b); len(c) != len(lags) || c.Sub(lags).Magnitude() > 1e-10 {
+		t.Errorf("expected the correlation %v, got %v", lags, c)
+	}
+
+	// a plan at least as large as the result gives the linear convolution in
+	// its first components
+	p := vector.NewRealFFT(20)
+	if c := p.Convolve(nil, a, b); c[:len(direct)].Sub(direct).Magnitude() > 1e-10 {
+		t.Errorf("expected the convolution in the first components, got %v", c)
+	}
+
+	if c := vector.Convolve(nil, b); len(c) != 0 {
+		t.Errorf("expected an empty convolution, got %v", c)
+	}
+}
+
+func TestFFTAllocations(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{64, 360, 97} {
+		p, rp := vector.NewFFT(n), vector.NewRealFFT(n)
+		x, signal := randomComplex(r, n), randomSignal(r, n)
+		dst, spectrum, out := make(vector.ComplexVector, n), make(vector.ComplexVector, n/2+1), make(vec, n)
+
+		allocs := testing.AllocsPerRun(10, func() {
+			p.Forward(dst, x)
+			p.Inverse(dst, dst)
+			rp.Forward(spectrum, signal)
+			rp.Inverse(out, spectrum)
+			rp.Convolve(out, signal, signal)
+		})
+
+		if allocs != 0 {
+			t.Errorf("expected no allocations with caller supplied buffers for size %v, got %v", n, allocs)
+		}
+	}
+}
+
+func BenchmarkFFT1024(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	p, x := vector.NewFFT(1024), randomComplex(r, 1024)
+	dst := make(vector.ComplexVector, 1024)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Forward(dst, x)
+	}
+}
+
+func BenchmarkRealFFT1024(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	p, x := vector.NewRealFFT(1024), randomSignal(r, 1024)
+	dst := make(vector.ComplexVector, 513)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.Forward(dst, x)
+	}
+}
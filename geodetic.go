@@ -0,0 +1,277 @@
+package vector
+
+import "math"
+
+// EarthRadius is the mean radius of the earth in meters, used by the great
+// circle functions which treat the earth as a sphere
+const EarthRadius = 6371008.8
+
+// Ellipsoid is a reference ellipsoid described by its semi-major axis in
+// meters and its flattening.
+//
+// All geodetic positions are given as Vector{lat, lon, alt}, with the latitude
+// and longitude in degrees and the altitude in meters above the ellipsoid. A
+// missing altitude is treated as 0.
+type Ellipsoid struct {
+	A float64
+	F float64
+}
+
+// WGS84 is the reference ellipsoid used by GPS
+var WGS84 = Ellipsoid{A: 6378137, F: 1 / 298.257223563}
+
+// b returns the semi-minor axis
+func (e Ellipsoid) b() float64 {
+	return e.A * (1 - e.F)
+}
+
+// e2 returns the square of the first eccentricity
+func (e Ellipsoid) e2() float64 {
+	return e.F * (2 - e.F)
+}
+
+// ToECEF converts a geodetic position to earth-centered, earth-fixed cartesian
+// coordinates in meters
+func (e Ellipsoid) ToECEF(geodetic Vector) Vector {
+	lat, lon, alt := radians(geodetic.X()), radians(geodetic.Y()), geodetic.Z()
+	sinLat, cosLat := math.Sincos(lat)
+	sinLon, cosLon := math.Sincos(lon)
+
+	// n is the prime vertical radius of curvature
+	n := e.A / math.Sqrt(1-e.e2()*sinLat*sinLat)
+
+	return Vector{
+		(n + alt) * cosLat * cosLon,
+		(n + alt) * cosLat * sinLon,
+		(n*(1-e.e2()) + alt) * sinLat,
+	}
+}
+
+// FromECEF converts earth-centered, earth-fixed cartesian coordinates in meters
+// to a geodetic position, it is the inverse of ToECEF
+func (e Ellipsoid) FromECEF(ecef Vector) Vector {
+	ex, ey, ez := ecef.X(), ecef.Y(), ecef.Z()
+	p, e2 := math.Hypot(ex, ey), e.e2()
+	lon := math.Atan2(ey, ex)
+
+	if p < 1e-9 {
+		return Vector{math.Copysign(90, ez), degrees(lon), math.Abs(ez) - e.b()}
+	}
+
+	// iterate the latitude starting from tan(lat) = z / (p (1 - e²)), which is
+	// the exact latitude of a position on the surface of the ellipsoid, so it
+	// converges to well below a millimeter within a few iterations for
+	// positions near the surface of the earth
+	lat := math.Atan2(ez, p*(1-e2))
+
+	for i := 0; i < 10; i++ {
+		sinLat := math.Sin(lat)
+		n := e.A / math.Sqrt(1-e2*sinLat*sinLat)
+		next := math.Atan2(ez+e2*n*sinLat, p)
+
+		if math.Abs(next-lat) < 1e-15 {
+			lat = next
+			break
+		}
+		lat = next
+	}
+
+	sinLat, cosLat := math.Sincos(lat)
+	alt := p*cosLat + ez*sinLat - e.A*math.Sqrt(1-e2*sinLat*sinLat)
+
+	return Vector{degrees(lat), degrees(lon), alt}
+}
+
+// ToENU converts a geodetic position to local east, north, up coordinates in
+// meters, relative to the geodetic origin
+func (e Ellipsoid) ToENU(geodetic, origin Vector) Vector {
+	d := sub(e.ToECEF(geodetic), e.ToECEF(origin))
+	sinLat, cosLat := math.Sincos(radians(origin.X()))
+	sinLon, cosLon := math.Sincos(radians(origin.Y()))
+
+	return Vector{
+		-sinLon*d[x] + cosLon*d[y],
+		-sinLat*cosLon*d[x] - sinLat*sinLon*d[y] + cosLat*d[z],
+		cosLat*cosLon*d[x] + cosLat*sinLon*d[y] + sinLat*d[z],
+	}
+}
+
+// FromENU converts local east, north, up coordinates in meters, relative to
+// the geodetic origin, to a geodetic position. It is the inverse of ToENU.
+func (e Ellipsoid) FromENU(enu, origin Vector) Vector {
+	east, north, up := enu.X(), enu.Y(), enu.Z()
+	sinLat, cosLat := math.Sincos(radians(origin.X()))
+	sinLon, cosLon := math.Sincos(radians(origin.Y()))
+
+	d := Vector{
+		-sinLon*east - sinLat*cosLon*north + cosLat*cosLon*up,
+		cosLon*east - sinLat*sinLon*north + cosLat*sinLon*up,
+		cosLat*north + sinLat*up,
+	}
+
+	return e.FromECEF(add(d, e.ToECEF(origin)))
+}
+
+// ToNED converts a geodetic position to local north, east, down coordinates in
+// meters, relative to the geodetic origin
+func (e Ellipsoid) ToNED(geodetic, origin Vector) Vector {
+	enu := e.ToENU(geodetic, origin)
+	return Vector{enu[y], enu[x], -enu[z]}
+}
+
+// FromNED converts local north, east, down coordinates in meters, relative to
+// the geodetic origin, to a geodetic position. It is the inverse of ToNED.
+func (e Ellipsoid) FromNED(ned, origin Vector) Vector {
+	return e.FromENU(Vector{ned.Y(), ned.X(), -ned.Z()}, origin)
+}
+
+// Vincenty returns the distance in meters along the ellipsoid between two
+// geodetic positions, using Vincenty's inverse formula. The altitudes are
+// ignored. It returns ErrNoConvergence for nearly antipodal positions, where
+// the formula does not converge.
+func (e Ellipsoid) Vincenty(a, b Vector) (float64, error) {
+	f, semiMinor := e.F, e.b()
+	u1 := math.Atan((1 - f) * math.Tan(radians(a.X())))
+	u2 := math.Atan((1 - f) * math.Tan(radians(b.X())))
+	l := radians(b.Y() - a.Y())
+
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	lambda := l
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma := math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+
+		if sinSigma == 0 {
+			return 0, nil
+		}
+
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha := 1 - sinAlpha*sinAlpha
+
+		// on the equator cos²α is 0 and the term is left out
+		var cos2SigmaM float64
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+
+		c := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+		previous := lambda
+		lambda = l + (1-c)*f*sinAlpha*
+			(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-previous) < 1e-12 {
+			u2 := cos2Alpha * (e.A*e.A - semiMinor*semiMinor) / (semiMinor * semiMinor)
+			k1 := 1 + u2/16384*(4096+u2*(-768+u2*(320-175*u2)))
+			k2 := u2 / 1024 * (256 + u2*(-128+u2*(74-47*u2)))
+			deltaSigma := k2 * sinSigma * (cos2SigmaM + k2/4*
+				(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+					k2/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+			return semiMinor * k1 * (sigma - deltaSigma), nil
+		}
+	}
+
+	return 0, ErrNoConvergence
+}
+
+// Haversine returns the great circle distance in meters between two geodetic
+// positions on a sphere with the radius EarthRadius. The altitudes are
+// ignored.
+func Haversine(a, b Vector) float64 {
+	lat1, lat2 := radians(a.X()), radians(b.X())
+	dLat, dLon := lat2-lat1, radians(b.Y()-a.Y())
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Bearing returns the initial bearing in degrees, clockwise from north in the
+// range [0, 360), of the great circle from the first to the second geodetic
+// position.
+func Bearing(a, b Vector) float64 {
+	lat1, lat2 := radians(a.X()), radians(b.X())
+	dLon := radians(b.Y() - a.Y())
+
+	bearing := math.Atan2(
+		math.Sin(dLon)*math.Cos(lat2),
+		math.Cos(lat1)*math.Sin(lat2)-math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon),
+	)
+
+	return math.Mod(degrees(bearing)+360, 360)
+}
+
+// Destination returns the geodetic position reached by travelling the given
+// distance in meters along a great circle from a geodetic position, starting
+// with the given bearing in degrees. The altitude of the start is kept.
+func Destination(a Vector, bearing, distance float64) Vector {
+	lat, lon := radians(a.X()), radians(a.Y())
+	theta, delta := radians(bearing), distance/EarthRadius
+
+	sinLat := math.Sin(lat)*math.Cos(delta) + math.Cos(lat)*math.Sin(delta)*math.Cos(theta)
+	lat2 := math.Asin(sinLat)
+	lon2 := lon + math.Atan2(
+		math.Sin(theta)*math.Sin(delta)*math.Cos(lat),
+		math.Cos(delta)-math.Sin(lat)*sinLat,
+	)
+
+	return Vector{degrees(lat2), normalizeLongitude(degrees(lon2)), a.Z()}
+}
+
+// GreatCircle returns the geodetic position at the fraction f of the way along
+// the great circle between two geodetic positions, where f = 0 is the first
+// and f = 1 the second. The altitude is interpolated linearly. It returns
+// ErrAntipodalPoints for positions on opposite sides of the earth, which have
+// no unique great circle between them.
+func GreatCircle(a, b Vector, f float64) (Vector, error) {
+	alt := a.Z() + (b.Z()-a.Z())*f
+	pa, pb := unitSphere(a), unitSphere(b)
+
+	// the angle between the positions from both its sine and cosine, which is
+	// accurate for both nearby and nearly antipodal positions
+	c, _ := cross(pa, pb)
+	sin := magnitude(c)
+	delta := math.Atan2(sin, dot(pa, pb))
+
+	if delta < 1e-12 {
+		return Vector{a.X(), a.Y(), alt}, nil
+	}
+
+	if math.Pi-delta < 1e-9 {
+		return nil, ErrAntipodalPoints
+	}
+
+	// interpolate the unit vectors of the two positions with slerp, which
+	// moves along the great circle between them
+	sa, sb := math.Sin((1-f)*delta)/sin, math.Sin(f*delta)/sin
+	p := add(scale(pa, sa), scale(pb, sb))
+
+	return Vector{
+		degrees(math.Atan2(p[z], math.Hypot(p[x], p[y]))),
+		degrees(math.Atan2(p[y], p[x])),
+		alt,
+	}, nil
+}
+
+func unitSphere(a Vector) Vector {
+	sinLat, cosLat := math.Sincos(radians(a.X()))
+	sinLon, cosLon := math.Sincos(radians(a.Y()))
+	return Vector{cosLat * cosLon, cosLat * sinLon, sinLat}
+}
+
+func normalizeLongitude(lon float64) float64 {
+	return math.Mod(lon+540, 360) - 180
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
@@ -0,0 +1,109 @@
+package vector_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func TestECEF(t *testing.T) {
+	ecef := vector.WGS84.ToECEF(vec{0, 0, 0})
+	if !ecef.Equal(vec{6378137, 0, 0}) {
+		t.Errorf("unexpected ecef coordinates %v", ecef)
+	}
+
+	for _, p := range []vec{{55.6761, 12.5683, 24}, {-33.8688, 151.2093, 0}, {89.9, -45, 1000}, {0, 180, -50}} {
+		back := vector.WGS84.FromECEF(vector.WGS84.ToECEF(p))
+		if math.Abs(back[0]-p[0]) > 1e-9 || math.Abs(back[1]-p[1]) > 1e-9 || math.Abs(back[2]-p[2]) > 1e-6 {
+			t.Errorf("ecef round trip of %v returned %v", p, back)
+		}
+	}
+}
+
+func TestENU(t *testing.T) {
+	origin := vec{55.6761, 12.5683, 0}
+	p := vec{55.6861, 12.5783, 100}
+
+	enu := vector.WGS84.ToENU(p, origin)
+	if enu.X() <= 0 || enu.Y() <= 0 {
+		t.Errorf("expected a position north east of the origin, got %v", enu)
+	}
+
+	if math.Abs(enu.Z()-100) > 20 {
+		t.Errorf("expected the up component to be close to the altitude, got %v", enu.Z())
+	}
+
+	back := vector.WGS84.FromENU(enu, origin)
+	if math.Abs(back[0]-p[0]) > 1e-9 || math.Abs(back[1]-p[1]) > 1e-9 || math.Abs(back[2]-p[2]) > 1e-6 {
+		t.Errorf("enu round trip returned %v", back)
+	}
+
+	ned := vector.WGS84.ToNED(p, origin)
+	if !ned.Equal(vec{enu[1], enu[0], -enu[2]}) {
+		t.Errorf("ned %v does not match enu %v", ned, enu)
+	}
+
+	if !vector.WGS84.FromNED(ned, origin).Equal(back) {
+		t.Error("ned round trip differs from enu round trip")
+	}
+}
+
+func TestGreatCircleDistances(t *testing.T) {
+	// the Flinders Peak to Buninyong example from Vincenty's paper
+	a := vec{-37.95103341666667, 144.42486788888888}
+	b := vec{-37.65282113888889, 143.92649552777777}
+
+	d, err := vector.WGS84.Vincenty(a, b)
+	if err != nil || math.Abs(d-54972.271) > 1e-3 {
+		t.Errorf("expected vincenty distance of 54972.271, got %v %v", d, err)
+	}
+
+	if h := vector.Haversine(a, b); math.Abs(h-d)/d > 0.005 {
+		t.Errorf("haversine distance %v is too far from vincenty distance %v", h, d)
+	}
+
+	if _, err := vector.WGS84.Vincenty(vec{0, 0}, vec{0.5, 179.7}); err != vector.ErrNoConvergence {
+		t.Error("expected nearly antipodal positions to not converge")
+	}
+}
+
+func TestBearingAndDestination(t *testing.T) {
+	if b := vector.Bearing(vec{0, 0}, vec{0, 10}); math.Abs(b-90) > 1e-9 {
+		t.Errorf("expected bearing 90, got %v", b)
+	}
+
+	if b := vector.Bearing(vec{10, 0}, vec{0, 0}); math.Abs(b-180) > 1e-9 {
+		t.Errorf("expected bearing 180, got %v", b)
+	}
+
+	start := vec{51.4778, -0.0015, 10}
+	end := vec{48.8566, 2.3522}
+
+	destination := vector.Destination(start, vector.Bearing(start, end), vector.Haversine(start, end))
+	if !destination.Equal(vec{end[0], end[1], 10}) {
+		t.Errorf("expected destination %v, got %v", end, destination)
+	}
+
+	middle, err := vector.GreatCircle(start, end, 0.5)
+	if err != nil || math.Abs(vector.Haversine(start, middle)-vector.Haversine(middle, end)) > 1e-6 {
+		t.Errorf("midpoint %v is not halfway (%v)", middle, err)
+	}
+
+	if last, err := vector.GreatCircle(start, end, 1); err != nil || !last.Equal(vec{end[0], end[1], 0}) {
+		t.Errorf("interpolation at 1 did not return the end position, got %v (%v)", last, err)
+	}
+
+	if same, err := vector.GreatCircle(start, start, 0.3); err != nil || !same.Equal(start) {
+		t.Errorf("expected the interpolation between one position to be that position, got %v (%v)", same, err)
+	}
+
+	if _, err := vector.GreatCircle(vec{10, 20}, vec{-10, -160}, 0.5); err != vector.ErrAntipodalPoints {
+		t.Errorf("expected ErrAntipodalPoints, got %v", err)
+	}
+
+	// nearly antipodal positions still have a unique great circle
+	if p, err := vector.GreatCircle(vec{0, 0}, vec{0, 179.9}, 0.5); err != nil || !p.Equal(vec{0, 89.95, 0}) {
+		t.Errorf("expected {0, 89.95, 0}, got %v (%v)", p, err)
+	}
+}
@@ -0,0 +1,148 @@
+package vector
+
+// Derivative writes the time derivative of the state at time t into dst. It
+// must not keep a reference to dst or state, as the integrators reuse them
+// between steps.
+type Derivative func(dst MutableVector, t float64, state Vector)
+
+// Integrator advances a state in place by one time step of size h from time t,
+// given the derivative of the state. Integrators preallocate their scratch
+// space, so stepping does not allocate as long as the derivative does not.
+//
+// The second order integrators, SemiImplicitEuler, Verlet and VelocityVerlet,
+// expect the state to be a position followed by a velocity of the same
+// dimension, and the derivative to be the velocity followed by the
+// acceleration. They only use the acceleration half of the derivative, which
+// should only depend on the position for them to be symplectic.
+type Integrator interface {
+	Step(state MutableVector, t, h float64, f Derivative)
+}
+
+// Euler is the explicit Euler method, the simplest first order integrator
+type Euler struct {
+	k MutableVector
+}
+
+// NewEuler returns an explicit Euler integrator for states of the given
+// dimension
+func NewEuler(dim int) *Euler {
+	return &Euler{k: make(MutableVector, dim)}
+}
+
+// Step advances the state by one time step
+func (e *Euler) Step(state MutableVector, t, h float64, f Derivative) {
+	f(e.k, t, Vector(state))
+	axpyUnitaryTo(state, h, e.k, state)
+}
+
+// SemiImplicitEuler is the symplectic Euler method, which first updates the
+// velocity and then the position using the new velocity
+type SemiImplicitEuler struct {
+	k MutableVector
+}
+
+// NewSemiImplicitEuler returns a semi-implicit Euler integrator for states of
+// the given dimension, which is twice the dimension of the position
+func NewSemiImplicitEuler(dim int) *SemiImplicitEuler {
+	return &SemiImplicitEuler{k: make(MutableVector, dim)}
+}
+
+// Step advances the state by one time step
+func (e *SemiImplicitEuler) Step(state MutableVector, t, h float64, f Derivative) {
+	n := len(state) / 2
+	position, velocity := state[:n], state[n:]
+
+	f(e.k, t, Vector(state))
+	axpyUnitaryTo(velocity, h, e.k[n:], velocity)
+	axpyUnitaryTo(position, h, velocity, position)
+}
+
+// Verlet is the position Verlet method, a second order symplectic integrator
+// which drifts the position half a step, kicks the velocity a full step and
+// drifts the position the remaining half step
+type Verlet struct {
+	k MutableVector
+}
+
+// NewVerlet returns a position Verlet integrator for states of the given
+// dimension, which is twice the dimension of the position
+func NewVerlet(dim int) *Verlet {
+	return &Verlet{k: make(MutableVector, dim)}
+}
+
+// Step advances the state by one time step
+func (v *Verlet) Step(state MutableVector, t, h float64, f Derivative) {
+	n := len(state) / 2
+	position, velocity := state[:n], state[n:]
+
+	axpyUnitaryTo(position, h/2, velocity, position)
+	f(v.k, t+h/2, Vector(state))
+	axpyUnitaryTo(velocity, h, v.k[n:], velocity)
+	axpyUnitaryTo(position, h/2, velocity, position)
+}
+
+// VelocityVerlet is the velocity Verlet method, a second order symplectic
+// integrator which updates the velocity with the average of the acceleration
+// before and after the position update
+type VelocityVerlet struct {
+	k0, k1 MutableVector
+}
+
+// NewVelocityVerlet returns a velocity Verlet integrator for states of the
+// given dimension, which is twice the dimension of the position
+func NewVelocityVerlet(dim int) *VelocityVerlet {
+	return &VelocityVerlet{
+		k0: make(MutableVector, dim),
+		k1: make(MutableVector, dim),
+	}
+}
+
+// Step advances the state by one time step
+func (v *VelocityVerlet) Step(state MutableVector, t, h float64, f Derivative) {
+	n := len(state) / 2
+	position, velocity := state[:n], state[n:]
+
+	f(v.k0, t, Vector(state))
+	axpyUnitaryTo(position, h, velocity, position)
+	axpyUnitaryTo(position, h*h/2, v.k0[n:], position)
+
+	f(v.k1, t+h, Vector(state))
+	axpyUnitaryTo(velocity, h/2, v.k0[n:], velocity)
+	axpyUnitaryTo(velocity, h/2, v.k1[n:], velocity)
+}
+
+// RK4 is the classic fourth order Runge-Kutta method
+type RK4 struct {
+	k1, k2, k3, k4, tmp MutableVector
+}
+
+// NewRK4 returns a fourth order Runge-Kutta integrator for states of the given
+// dimension
+func NewRK4(dim int) *RK4 {
+	return &RK4{
+		k1:  make(MutableVector, dim),
+		k2:  make(MutableVector, dim),
+		k3:  make(MutableVector, dim),
+		k4:  make(MutableVector, dim),
+		tmp: make(MutableVector, dim),
+	}
+}
+
+// Step advances the state by one time step
+func (r *RK4) Step(state MutableVector, t, h float64, f Derivative) {
+	f(r.k1, t, Vector(state))
+
+	axpyUnitaryTo(r.tmp, h/2, r.k1, state)
+	f(r.k2, t+h/2, Vector(r.tmp))
+
+	axpyUnitaryTo(r.tmp, h/2, r.k2, state)
+	f(r.k3, t+h/2, Vector(r.tmp))
+
+	axpyUnitaryTo(r.tmp, h, r.k3, state)
+	f(r.k4, t+h, Vector(r.tmp))
+
+	axpyUnitaryTo(state, h/6, r.k1, state)
+	axpyUnitaryTo(state, h/3, r.k2, state)
+	axpyUnitaryTo(state, h/3, r.k3, state)
+	axpyUnitaryTo(state, h/6, r.k4, state)
+}
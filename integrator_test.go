@@ -0,0 +1,77 @@
+package vector_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+// oscillator is a harmonic oscillator with unit mass and stiffness, the state
+// is the position followed by the velocity
+func oscillator(dst vector.MutableVector, t float64, state vec) {
+	dst[0], dst[1] = state[1], -state[0]
+}
+
+func energy(state vector.MutableVector) float64 {
+	return (state[0]*state[0] + state[1]*state[1]) / 2
+}
+
+func TestIntegratorEnergyDrift(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		integrator vector.Integrator
+		maxDrift   float64
+	}{
+		{"SemiImplicitEuler", vector.NewSemiImplicitEuler(2), 0.01},
+		{"Verlet", vector.NewVerlet(2), 1e-4},
+		{"VelocityVerlet", vector.NewVelocityVerlet(2), 1e-4},
+		{"RK4", vector.NewRK4(2), 1e-6},
+	} {
+		state, h := vector.MutableVector{1, 0}, 0.01
+
+		for i := 0; i < 10000; i++ {
+			test.integrator.Step(state, float64(i)*h, h, oscillator)
+		}
+
+		if drift := math.Abs(energy(state) - 0.5); drift > test.maxDrift {
+			t.Errorf("%v drifted %v in energy", test.name, drift)
+		}
+
+		if math.Abs(state[0]-math.Cos(100)) > 0.05 {
+			t.Errorf("%v position %v is too far from the exact solution %v", test.name, state[0], math.Cos(100))
+		}
+	}
+}
+
+func TestEulerGainsEnergy(t *testing.T) {
+	state, h, euler := vector.MutableVector{1, 0}, 0.01, vector.NewEuler(2)
+
+	for i := 0; i < 1000; i++ {
+		euler.Step(state, float64(i)*h, h, oscillator)
+	}
+
+	if energy(state) <= 0.5 {
+		t.Error("expected explicit euler to gain energy on a harmonic oscillator")
+	}
+}
+
+func TestIntegratorAllocations(t *testing.T) {
+	state := vector.MutableVector{1, 0}
+
+	for _, integrator := range []vector.Integrator{
+		vector.NewEuler(2),
+		vector.NewSemiImplicitEuler(2),
+		vector.NewVerlet(2),
+		vector.NewVelocityVerlet(2),
+		vector.NewRK4(2),
+	} {
+		allocs := testing.AllocsPerRun(100, func() {
+			integrator.Step(state, 0, 0.01, oscillator)
+		})
+
+		if allocs != 0 {
+			t.Errorf("%T allocated %v times per step", integrator, allocs)
+		}
+	}
+}
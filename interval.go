@@ -0,0 +1,218 @@
+package vector
+
+import "math"
+
+// Interval is a closed range of real numbers from Lo to Hi. Every operation on
+// intervals rounds its bounds outwards, so the result is guaranteed to contain
+// the exact result of the operation on any numbers within the intervals,
+// despite the rounding of floating point arithmetic.
+type Interval struct {
+	Lo, Hi float64
+}
+
+// NewInterval returns the interval between two bounds in any order
+func NewInterval(a, b float64) Interval {
+	if a > b {
+		a, b = b, a
+	}
+	return Interval{a, b}
+}
+
+// Exact returns the interval only holding the given number
+func Exact(v float64) Interval {
+	return Interval{v, v}
+}
+
+// Add returns the sum of two intervals
+func (a Interval) Add(b Interval) Interval {
+	return outward(a.Lo+b.Lo, a.Hi+b.Hi)
+}
+
+// Sub returns the difference of two intervals
+func (a Interval) Sub(b Interval) Interval {
+	return outward(a.Lo-b.Hi, a.Hi-b.Lo)
+}
+
+// Mul returns the product of two intervals
+func (a Interval) Mul(b Interval) Interval {
+	p := [4]float64{a.Lo * b.Lo, a.Lo * b.Hi, a.Hi * b.Lo, a.Hi * b.Hi}
+	lo, hi := p[0], p[0]
+	for _, v := range p[1:] {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	return outward(lo, hi)
+}
+
+// Scale returns the interval multiplied by a number
+func (a Interval) Scale(size float64) Interval {
+	return a.Mul(Exact(size))
+}
+
+// Sqr returns the square of the interval, which unlike a.Mul(a) knows both
+// factors are the same number and so never goes below zero
+func (a Interval) Sqr() Interval {
+	lo, hi := a.Lo*a.Lo, a.Hi*a.Hi
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+
+	if a.Lo <= 0 && a.Hi >= 0 {
+		return Interval{0, math.Nextafter(hi, math.Inf(1))}
+	}
+
+	return outward(lo, hi)
+}
+
+// Sqrt returns the square root of the interval, where the part below zero is
+// left out
+func (a Interval) Sqrt() Interval {
+	r := outward(math.Sqrt(math.Max(0, a.Lo)), math.Sqrt(math.Max(0, a.Hi)))
+	r.Lo = math.Max(0, r.Lo)
+	return r
+}
+
+// Width returns the distance between the bounds of the interval
+func (a Interval) Width() float64 {
+	return a.Hi - a.Lo
+}
+
+// Contains reports whether the number lies within the interval
+func (a Interval) Contains(v float64) bool {
+	return a.Lo <= v && v <= a.Hi
+}
+
+// outward returns the interval with the rounded bounds moved out by one unit in
+// the last place, which contains the exact bounds as every operation rounds to
+// the nearest number
+func outward(lo, hi float64) Interval {
+	return Interval{math.Nextafter(lo, math.Inf(-1)), math.Nextafter(hi, math.Inf(1))}
+}
+
+// IntervalVector is a vector where every component is an interval, which is a
+// box guaranteed to contain the exact result of the operations on it
+type IntervalVector []Interval
+
+// Intervals returns the interval vector only holding the given vector
+func Intervals(v Vector) IntervalVector {
+	result := make(IntervalVector, len(v))
+	for i := range v {
+		result[i] = Exact(v[i])
+	}
+	return result
+}
+
+// Box returns the interval vector spanned by the componentwise min and max
+func Box(min, max Vector) IntervalVector {
+	result := make(IntervalVector, len(min))
+	for i := range min {
+		result[i] = NewInterval(min[i], component(max, i))
+	}
+	return result
+}
+
+// Lo returns the lower bounds of the interval vector
+func (a IntervalVector) Lo() Vector {
+	result := make(Vector, len(a))
+	for i := range a {
+		result[i] = a[i].Lo
+	}
+	return result
+}
+
+// Hi returns the upper bounds of the interval vector
+func (a IntervalVector) Hi() Vector {
+	result := make(Vector, len(a))
+	for i := range a {
+		result[i] = a[i].Hi
+	}
+	return result
+}
+
+// Add returns the sum of two interval vectors
+func (a IntervalVector) Add(b IntervalVector) IntervalVector {
+	result := make(IntervalVector, len(a))
+	for i := range a {
+		result[i] = a[i].Add(b.component(i))
+	}
+	return result
+}
+
+// Sub returns the difference of two interval vectors
+func (a IntervalVector) Sub(b IntervalVector) IntervalVector {
+	result := make(IntervalVector, len(a))
+	for i := range a {
+		result[i] = a[i].Sub(b.component(i))
+	}
+	return result
+}
+
+// Scale returns the interval vector scaled by a number
+func (a IntervalVector) Scale(size float64) IntervalVector {
+	result := make(IntervalVector, len(a))
+	for i := range a {
+		result[i] = a[i].Scale(size)
+	}
+	return result
+}
+
+// Dot product of two interval vectors
+func (a IntervalVector) Dot(b IntervalVector) Interval {
+	var result Interval
+	for i := 0; i < len(a) && i < len(b); i++ {
+		result = result.Add(a[i].Mul(b[i]))
+	}
+	return result
+}
+
+// Magnitude of an interval vector
+func (a IntervalVector) Magnitude() Interval {
+	var result Interval
+	for i := range a {
+		result = result.Add(a[i].Sqr())
+	}
+	return result.Sqrt()
+}
+
+// Cross product of two 3-dimensional interval vectors, it returns
+// ErrNot3Dimensional for any other dimension
+func (a IntervalVector) Cross(b IntervalVector) (IntervalVector, error) {
+	if len(a) != 3 || len(b) != 3 {
+		return nil, ErrNot3Dimensional
+	}
+
+	return IntervalVector{
+		a[y].Mul(b[z]).Sub(b[y].Mul(a[z])),
+		a[z].Mul(b[x]).Sub(b[z].Mul(a[x])),
+		a[x].Mul(b[y]).Sub(b[x].Mul(a[y])),
+	}, nil
+}
+
+// Contains reports whether the point lies within the interval vector, where
+// missing components of the point count as zero
+func (a IntervalVector) Contains(point Vector) bool {
+	for i := range a {
+		if !a[i].Contains(component(point, i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Within reports whether the interval vector lies entirely within another, so
+// every point it holds is certain to be in the other as well
+func (a IntervalVector) Within(b IntervalVector) bool {
+	for i := range a {
+		c := b.component(i)
+		if a[i].Lo < c.Lo || a[i].Hi > c.Hi {
+			return false
+		}
+	}
+	return true
+}
+
+func (a IntervalVector) component(i int) Interval {
+	if i < len(a) {
+		return a[i]
+	}
+	return Interval{}
+}
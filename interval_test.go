@@ -0,0 +1,147 @@
+package vector_test
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+// contains reports whether the exact rational lies within the interval
+func contains(i vector.Interval, exact *big.Rat) bool {
+	lo, hi := new(big.Rat).SetFloat64(i.Lo), new(big.Rat).SetFloat64(i.Hi)
+	return lo.Cmp(exact) <= 0 && exact.Cmp(hi) <= 0
+}
+
+func rat(v float64) *big.Rat {
+	return new(big.Rat).SetFloat64(v)
+}
+
+func TestIntervalOutwardRounding(t *testing.T) {
+	// 0.1 + 0.2 rounds to a float above 0.3, the interval has to contain the
+	// exact sum of the two floats
+	sum := vector.Exact(0.1).Add(vector.Exact(0.2))
+	if !contains(sum, new(big.Rat).Add(rat(0.1), rat(0.2))) || sum.Width() == 0 {
+		t.Errorf("expected %v to contain the exact sum", sum)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		a, b := r.NormFloat64()*1e3, r.NormFloat64()*1e-3
+		ea, eb := rat(a), rat(b)
+
+		if !contains(vector.Exact(a).Add(vector.Exact(b)), new(big.Rat).Add(ea, eb)) {
+			t.Fatalf("%v + %v is not contained", a, b)
+		}
+
+		if !contains(vector.Exact(a).Sub(vector.Exact(b)), new(big.Rat).Sub(ea, eb)) {
+			t.Fatalf("%v - %v is not contained", a, b)
+		}
+
+		if !contains(vector.Exact(a).Mul(vector.Exact(b)), new(big.Rat).Mul(ea, eb)) {
+			t.Fatalf("%v * %v is not contained", a, b)
+		}
+	}
+}
+
+func TestInterval(t *testing.T) {
+	a := vector.NewInterval(2, -1)
+	if a.Lo != -1 || a.Hi != 2 {
+		t.Errorf("expected the bounds to be ordered, got %v", a)
+	}
+
+	if s := a.Sqr(); s.Lo != 0 || !s.Contains(4) || s.Contains(-0.1) {
+		t.Errorf("expected the square to be from 0 to 4, got %v", s)
+	}
+
+	if m := a.Mul(a); !m.Contains(-2) {
+		t.Errorf("expected the product to treat the factors as independent, got %v", m)
+	}
+
+	if s := vector.NewInterval(-1, 4).Sqrt(); s.Lo != 0 || !s.Contains(2) {
+		t.Errorf("expected the square root to be from 0 to 2, got %v", s)
+	}
+
+	if s := vector.NewInterval(-2, 3).Scale(-2); !s.Contains(-6) || !s.Contains(4) || s.Contains(5) {
+		t.Errorf("expected a scaled interval from -6 to 4, got %v", s)
+	}
+}
+
+func TestIntervalVector(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 200; i++ {
+		a := vec{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}
+		b := vec{r.NormFloat64(), r.NormFloat64(), r.NormFloat64()}
+		ia, ib := vector.Intervals(a), vector.Intervals(b)
+
+		if !ia.Add(ib).Contains(a.Add(b)) || !ia.Sub(ib).Contains(a.Sub(b)) || !ia.Scale(3).Contains(a.Scale(3)) {
+			t.Fatalf("expected the intervals to contain the float results of %v and %v", a, b)
+		}
+
+		exact := new(big.Rat)
+		for k := range a {
+			exact.Add(exact, new(big.Rat).Mul(rat(a[k]), rat(b[k])))
+		}
+		if !contains(ia.Dot(ib), exact) {
+			t.Fatalf("expected %v to contain the exact dot product", ia.Dot(ib))
+		}
+
+		// the magnitude is checked through its square, which is rational
+		squared := new(big.Rat)
+		for k := range a {
+			squared.Add(squared, new(big.Rat).Mul(rat(a[k]), rat(a[k])))
+		}
+		m := ia.Magnitude()
+		lo, hi := new(big.Rat).Mul(rat(m.Lo), rat(m.Lo)), new(big.Rat).Mul(rat(m.Hi), rat(m.Hi))
+		if lo.Cmp(squared) > 0 || squared.Cmp(hi) > 0 {
+			t.Fatalf("expected %v to contain the exact magnitude", m)
+		}
+
+		c, err := ia.Cross(ib)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for k, j := range [3][2]int{{1, 2}, {2, 0}, {0, 1}} {
+			e := new(big.Rat).Sub(
+				new(big.Rat).Mul(rat(a[j[0]]), rat(b[j[1]])),
+				new(big.Rat).Mul(rat(a[j[1]]), rat(b[j[0]])),
+			)
+			if !contains(c[k], e) {
+				t.Fatalf("expected %v to contain the exact cross product", c)
+			}
+		}
+	}
+
+	if _, err := vector.Intervals(vec{1, 2}).Cross(vector.Intervals(vec{1, 2, 3})); err != vector.ErrNot3Dimensional {
+		t.Errorf("expected ErrNot3Dimensional, got %v", err)
+	}
+}
+
+func TestIntervalVectorContainment(t *testing.T) {
+	region := vector.Box(vec{0, 0, 0}, vec{1, 1, 1})
+
+	// a point computed from uncertain inputs is certified to be in the region
+	// when the whole box of possible results is within it
+	p := vector.Box(vec{0.2, 0.3, 0.1}, vec{0.25, 0.35, 0.15})
+	moved := p.Add(vector.Intervals(vec{0.5, 0.5, 0.5}))
+
+	if !moved.Within(region) || !region.Contains(moved.Lo()) || !region.Contains(moved.Hi()) {
+		t.Errorf("expected %v to be within the region", moved)
+	}
+
+	if moved.Add(vector.Intervals(vec{0.3})).Within(region) {
+		t.Error("expected a box reaching past the region not to be within it")
+	}
+
+	if region.Contains(vec{0.5, 1.5, 0.5}) || !region.Contains(vec{0.5, 0.5}) {
+		t.Error("unexpected containment of points")
+	}
+
+	if m := vector.Intervals(vec{3, 4}).Magnitude(); !m.Contains(5) || m.Width() > 1e-14 || math.IsNaN(m.Lo) {
+		t.Errorf("expected a tight interval around 5, got %v", m)
+	}
+}
@@ -0,0 +1,409 @@
+package vector
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// ClusterOptions configures KMeans and KMedoids. The zero value is valid and
+// will use the defaults described on each field.
+type ClusterOptions struct {
+	// Metric is the distance between two vectors, defaults to Euclidean
+	Metric Metric
+	// MaxIterations is the upper bound of iterations, defaults to 300
+	MaxIterations int
+	// Tolerance stops KMeans when no centroid moves further than it between two
+	// iterations, defaults to 1e-8
+	Tolerance float64
+	// BatchSize enables mini-batch k-means when it is larger than 0, where each
+	// iteration only looks at a random sample of the given size
+	BatchSize int
+	// Source is used for seeding and sampling, defaults to a source seeded
+	// with 1 so results are deterministic unless asked otherwise
+	Source rand.Source
+	// Workers is the number of goroutines used when assigning vectors to
+	// clusters, defaults to GOMAXPROCS
+	Workers int
+}
+
+// Clustering is the result of KMeans or KMedoids
+type Clustering struct {
+	// Centroids are the centers of the clusters, for KMedoids they are the
+	// medoids which are vectors from the dataset
+	Centroids []Vector
+	// Assignments holds the index of the cluster for each vector in the
+	// dataset
+	Assignments []int
+	// Inertia is the sum of the squared distances from each vector to its
+	// centroid
+	Inertia float64
+	// Iterations is the number of iterations done before stopping
+	Iterations int
+}
+
+// KMeans partitions a dataset into k clusters with Lloyd's algorithm, or the
+// mini-batch variant if a batch size is given, seeded with k-means++.
+//
+// The centroids are computed as the mean of their clusters, which minimizes
+// the squared euclidean distance. Other metrics only change how vectors are
+// assigned, use KMedoids if the metric is far from euclidean.
+func KMeans(data []Vector, k int, opts ClusterOptions) (Clustering, error) {
+	if err := validateClusters(data, k); err != nil {
+		return Clustering{}, err
+	}
+
+	opts = opts.withDefaults()
+	r := rand.New(opts.Source)
+	centroids := seedPlusPlus(data, k, opts.Metric, r)
+
+	var iterations int
+	if opts.BatchSize > 0 {
+		iterations = miniBatch(data, centroids, opts, r)
+	} else {
+		iterations = lloyd(data, centroids, opts)
+	}
+
+	assignments, distances := assign(data, centroids, opts)
+
+	return Clustering{
+		Centroids:   centroids,
+		Assignments: assignments,
+		Inertia:     inertia(distances),
+		Iterations:  iterations,
+	}, nil
+}
+
+// KMedoids partitions a dataset into k clusters with the PAM algorithm, where
+// every cluster is represented by one of the vectors in the dataset. This makes
+// it work with any metric, but it needs all pairwise distances of the dataset.
+func KMedoids(data []Vector, k int, opts ClusterOptions) (Clustering, error) {
+	if err := validateClusters(data, k); err != nil {
+		return Clustering{}, err
+	}
+
+	opts = opts.withDefaults()
+	n := len(data)
+
+	d := make([]Vector, n)
+	for i := range d {
+		d[i] = make(Vector, n)
+		for j := 0; j < i; j++ {
+			d[i][j] = opts.Metric(data[i], data[j])
+			d[j][i] = d[i][j]
+		}
+	}
+
+	medoids, nearest := buildMedoids(d, k), make(Vector, n)
+
+	cost := func(medoids []int) float64 {
+		var total float64
+		for i := range d {
+			nearest[i] = math.Inf(1)
+			for _, m := range medoids {
+				nearest[i] = math.Min(nearest[i], d[i][m])
+			}
+			total += nearest[i]
+		}
+		return total
+	}
+
+	current, iterations := cost(medoids), 0
+
+	for iterations < opts.MaxIterations {
+		iterations++
+		improved := false
+
+		for mi := range medoids {
+			for candidate := 0; candidate < n; candidate++ {
+				if isMedoid(medoids, candidate) {
+					continue
+				}
+
+				previous := medoids[mi]
+				medoids[mi] = candidate
+
+				if c := cost(medoids); c < current-1e-12 {
+					current, improved = c, true
+				} else {
+					medoids[mi] = previous
+				}
+			}
+		}
+
+		if !improved {
+			break
+		}
+	}
+
+	centroids := make([]Vector, k)
+	for i, m := range medoids {
+		centroids[i] = clone(data[m])
+	}
+
+	assignments, distances := assign(data, centroids, opts)
+
+	return Clustering{
+		Centroids:   centroids,
+		Assignments: assignments,
+		Inertia:     inertia(distances),
+		Iterations:  iterations,
+	}, nil
+}
+
+func (opts ClusterOptions) withDefaults() ClusterOptions {
+	if opts.Metric == nil {
+		opts.Metric = Euclidean
+	}
+
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = 300
+	}
+
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = 1e-8
+	}
+
+	if opts.Source == nil {
+		opts.Source = rand.NewSource(1)
+	}
+
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+
+	return opts
+}
+
+func validateClusters(data []Vector, k int) error {
+	if len(data) == 0 {
+		return ErrEmptyDataset
+	}
+
+	if k <= 0 || k > len(data) {
+		return ErrNotValidClusterCount
+	}
+
+	return nil
+}
+
+// seedPlusPlus picks the initial centroids with k-means++, where every next
+// centroid is sampled with a probability proportional to its squared distance
+// from the closest centroid picked so far. The centroids have the dimension of
+// the first vector.
+func seedPlusPlus(data []Vector, k int, metric Metric, r *rand.Rand) []Vector {
+	dim := len(data[0])
+	centroids := make([]Vector, 0, k)
+	centroids = append(centroids, resize(data[r.Intn(len(data))], dim))
+
+	weights := make(Vector, len(data))
+	for i := range weights {
+		weights[i] = math.Inf(1)
+	}
+
+	for len(centroids) < k {
+		var total float64
+		last := centroids[len(centroids)-1]
+
+		for i, v := range data {
+			d := metric(v, last)
+			weights[i] = math.Min(weights[i], d*d)
+			total += weights[i]
+		}
+
+		next := len(data) - 1
+		if total > 0 {
+			target := r.Float64() * total
+			for i, w := range weights {
+				if target -= w; target < 0 {
+					next = i
+					break
+				}
+			}
+		} else {
+			next = r.Intn(len(data))
+		}
+
+		centroids = append(centroids, resize(data[next], dim))
+	}
+
+	return centroids
+}
+
+func lloyd(data []Vector, centroids []Vector, opts ClusterOptions) int {
+	dim := len(data[0])
+	sums, counts := zeros(len(centroids), dim), make([]int, len(centroids))
+
+	for iteration := 1; iteration <= opts.MaxIterations; iteration++ {
+		assignments, _ := assign(data, centroids, opts)
+
+		for i := range sums {
+			scale(sums[i], 0)
+			counts[i] = 0
+		}
+
+		for i, c := range assignments {
+			for j := 0; j < dim && j < len(data[i]); j++ {
+				sums[c][j] += data[i][j]
+			}
+			counts[c]++
+		}
+
+		var shift float64
+		for i := range centroids {
+			// an empty cluster keeps its centroid
+			if counts[i] == 0 {
+				continue
+			}
+
+			scale(sums[i], 1/float64(counts[i]))
+			shift = math.Max(shift, squaredEuclidean(sums[i], centroids[i]))
+			copy(centroids[i], sums[i])
+		}
+
+		if shift <= opts.Tolerance*opts.Tolerance {
+			return iteration
+		}
+	}
+
+	return opts.MaxIterations
+}
+
+// miniBatch runs the mini-batch k-means by Sculley, where the centroids are
+// moved towards the sampled vectors with a per centroid learning rate
+func miniBatch(data []Vector, centroids []Vector, opts ClusterOptions, r *rand.Rand) int {
+	size := opts.BatchSize
+	if size > len(data) {
+		size = len(data)
+	}
+
+	batch, counts := make([]Vector, size), make([]int, len(centroids))
+	previous := cloneMatrix(centroids)
+
+	for iteration := 1; iteration <= opts.MaxIterations; iteration++ {
+		for i := range batch {
+			batch[i] = data[r.Intn(len(data))]
+		}
+
+		assignments, _ := assign(batch, centroids, opts)
+
+		for i, c := range assignments {
+			counts[c]++
+			eta := 1 / float64(counts[c])
+			for j := range centroids[c] {
+				centroids[c][j] += eta * (component(batch[i], j) - centroids[c][j])
+			}
+		}
+
+		var shift float64
+		for i := range centroids {
+			shift = math.Max(shift, squaredEuclidean(previous[i], centroids[i]))
+			copy(previous[i], centroids[i])
+		}
+
+		if shift <= opts.Tolerance*opts.Tolerance {
+			return iteration
+		}
+	}
+
+	return opts.MaxIterations
+}
+
+// assign finds the closest centroid for every vector, splitting the work
+// between the configured number of workers. The distances are returned per
+// vector so any reduction over them is independent of the number of workers.
+func assign(data []Vector, centroids []Vector, opts ClusterOptions) ([]int, Vector) {
+	assignments, distances := make([]int, len(data)), make(Vector, len(data))
+
+	work := func(from, to int) {
+		for i := from; i < to; i++ {
+			best, bestDistance := 0, math.Inf(1)
+			for c := range centroids {
+				if d := opts.Metric(data[i], centroids[c]); d < bestDistance {
+					best, bestDistance = c, d
+				}
+			}
+			assignments[i], distances[i] = best, bestDistance
+		}
+	}
+
+	workers := opts.Workers
+	if workers <= 1 || len(data) < 2*workers {
+		work(0, len(data))
+		return assignments, distances
+	}
+
+	var wg sync.WaitGroup
+	chunk := (len(data) + workers - 1) / workers
+
+	for from := 0; from < len(data); from += chunk {
+		to := from + chunk
+		if to > len(data) {
+			to = len(data)
+		}
+
+		wg.Add(1)
+		go func(from, to int) {
+			defer wg.Done()
+			work(from, to)
+		}(from, to)
+	}
+
+	wg.Wait()
+	return assignments, distances
+}
+
+func inertia(distances Vector) float64 {
+	var result float64
+	for _, d := range distances {
+		result += d * d
+	}
+	return result
+}
+
+// buildMedoids is the greedy BUILD step of PAM, which picks the medoids one at
+// a time so that each pick lowers the total distance the most
+func buildMedoids(d []Vector, k int) []int {
+	n := len(d)
+	medoids, nearest := make([]int, 0, k), make(Vector, n)
+
+	for i := range nearest {
+		nearest[i] = math.Inf(1)
+	}
+
+	for len(medoids) < k {
+		best, bestCost := -1, math.Inf(1)
+
+		for candidate := 0; candidate < n; candidate++ {
+			if isMedoid(medoids, candidate) {
+				continue
+			}
+
+			var cost float64
+			for i := range d {
+				cost += math.Min(nearest[i], d[i][candidate])
+			}
+
+			if cost < bestCost {
+				best, bestCost = candidate, cost
+			}
+		}
+
+		medoids = append(medoids, best)
+		for i := range nearest {
+			nearest[i] = math.Min(nearest[i], d[i][best])
+		}
+	}
+
+	return medoids
+}
+
+func isMedoid(medoids []int, i int) bool {
+	for _, m := range medoids {
+		if m == i {
+			return true
+		}
+	}
+	return false
+}
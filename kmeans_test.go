@@ -0,0 +1,115 @@
+package vector_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func blobs(n int, centers ...vec) []vec {
+	r := rand.New(rand.NewSource(2))
+	data := make([]vec, 0, n*len(centers))
+	for _, c := range centers {
+		for i := 0; i < n; i++ {
+			data = append(data, c.Add(vec{r.NormFloat64() * 0.3, r.NormFloat64() * 0.3}))
+		}
+	}
+	return data
+}
+
+func assertClusters(t *testing.T, result vector.Clustering, n, k int) {
+	t.Helper()
+
+	for c := 0; c < k; c++ {
+		label := result.Assignments[c*n]
+		for i := c * n; i < (c+1)*n; i++ {
+			if result.Assignments[i] != label {
+				t.Fatalf("vector %v was not assigned to the cluster of its blob", i)
+			}
+		}
+	}
+}
+
+func TestKMeans(t *testing.T) {
+	data := blobs(50, vec{0, 0}, vec{10, 10}, vec{-10, 10})
+
+	result, err := vector.KMeans(data, 3, vector.ClusterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertClusters(t, result, 50, 3)
+
+	serial, _ := vector.KMeans(data, 3, vector.ClusterOptions{Workers: 1})
+	if serial.Inertia != result.Inertia {
+		t.Error("result depends on the number of workers")
+	}
+}
+
+func TestKMeansMixedDimensions(t *testing.T) {
+	data := []vec{{0, 0}, {1}, {5, 5, 5}, {6, 5}}
+
+	for _, batch := range []int{0, 4} {
+		result, err := vector.KMeans(data, 2, vector.ClusterOptions{BatchSize: batch})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range result.Centroids {
+			if len(c) != 2 {
+				t.Fatalf("expected 2-dimensional centroids, got %v", result.Centroids)
+			}
+		}
+
+		if batch == 0 && !result.Centroids[result.Assignments[2]].Equal(vec{5.5, 5}) {
+			t.Errorf("expected the centroid {5.5, 5}, got %v", result.Centroids)
+		}
+	}
+}
+
+func TestMiniBatchKMeans(t *testing.T) {
+	data := blobs(100, vec{0, 0}, vec{10, 10})
+
+	result, err := vector.KMeans(data, 2, vector.ClusterOptions{
+		BatchSize: 20,
+		Source:    rand.NewSource(7),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertClusters(t, result, 100, 2)
+}
+
+func TestKMedoids(t *testing.T) {
+	data := blobs(20, vec{0, 0}, vec{10, 10}, vec{-10, 10})
+
+	result, err := vector.KMedoids(data, 3, vector.ClusterOptions{Metric: vector.Manhattan})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertClusters(t, result, 20, 3)
+
+	for _, c := range result.Centroids {
+		found := false
+		for _, v := range data {
+			found = found || v.Equal(c)
+		}
+
+		if !found {
+			t.Errorf("medoid %v is not a vector from the dataset", c)
+		}
+	}
+}
+
+func TestClusterCount(t *testing.T) {
+	if _, err := vector.KMeans([]vec{{1}}, 2, vector.ClusterOptions{}); err != vector.ErrNotValidClusterCount {
+		t.Error("did not return ErrNotValidClusterCount when k is larger than the dataset")
+	}
+
+	if _, err := vector.KMedoids(nil, 1, vector.ClusterOptions{}); err != vector.ErrEmptyDataset {
+		t.Error("did not return ErrEmptyDataset for an empty dataset")
+	}
+}
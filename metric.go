@@ -0,0 +1,106 @@
+package vector
+
+import "math"
+
+// Metric is a function returning the distance between two vectors. Missing
+// components in the shorter of the two vectors are treated as 0.
+type Metric func(a, b Vector) float64
+
+// Euclidean returns the straight line distance between two vectors
+func Euclidean(a, b Vector) float64 {
+	return math.Sqrt(squaredEuclidean(a, b))
+}
+
+// Manhattan returns the sum of the absolute differences between the
+// components of two vectors
+func Manhattan(a, b Vector) float64 {
+	var result float64
+	for i, n := 0, maxLen(a, b); i < n; i++ {
+		result += math.Abs(component(a, i) - component(b, i))
+	}
+	return result
+}
+
+// Chebyshev returns the largest absolute difference between the components of
+// two vectors
+func Chebyshev(a, b Vector) float64 {
+	var result float64
+	for i, n := 0, maxLen(a, b); i < n; i++ {
+		result = math.Max(result, math.Abs(component(a, i)-component(b, i)))
+	}
+	return result
+}
+
+// Cosine returns one minus the cosine similarity of two vectors, which is 0
+// for vectors pointing in the same direction and 2 for opposite vectors. It
+// does not satisfy the triangle inequality, see Angular for a metric.
+func Cosine(a, b Vector) float64 {
+	l := magnitude(a) * magnitude(b)
+	if l == 0 {
+		return 1
+	}
+
+	var d float64
+	for i := 0; i < len(a) && i < len(b); i++ {
+		d += a[i] * b[i]
+	}
+
+	return 1 - d/l
+}
+
+// Angular returns the angle in radians between two vectors, from 0 for
+// vectors pointing in the same direction to π for opposite vectors. Unlike
+// Cosine it satisfies the triangle inequality, so it can be used with a VPTree.
+func Angular(a, b Vector) float64 {
+	la, lb := magnitude(a), magnitude(b)
+	if la > 0 {
+		la = 1 / la
+	}
+	if lb > 0 {
+		lb = 1 / lb
+	}
+
+	// the angle between the unit vectors from the lengths of their difference
+	// and sum, which unlike the arc cosine is accurate for small angles
+	var difference, sum float64
+	for i, n := 0, maxLen(a, b); i < n; i++ {
+		u, v := component(a, i)*la, component(b, i)*lb
+		difference += (u - v) * (u - v)
+		sum += (u + v) * (u + v)
+	}
+
+	return 2 * math.Atan2(math.Sqrt(difference), math.Sqrt(sum))
+}
+
+func squaredEuclidean(a, b []float64) float64 {
+	if len(a) == 2 && len(b) == 2 {
+		dx, dy := a[x]-b[x], a[y]-b[y]
+		return dx*dx + dy*dy
+	}
+
+	if len(a) == 3 && len(b) == 3 {
+		dx, dy, dz := a[x]-b[x], a[y]-b[y], a[z]-b[z]
+		return dx*dx + dy*dy + dz*dz
+	}
+
+	var result float64
+	for i, n := 0, maxLen(a, b); i < n; i++ {
+		d := component(a, i) - component(b, i)
+		result += d * d
+	}
+	return result
+}
+
+func component(a []float64, i int) float64 {
+	if i >= 0 && i < len(a) {
+		return a[i]
+	}
+	return 0
+}
+
+func maxLen(a, b []float64) int {
+	if len(a) > len(b) {
+		return len(a)
+	}
+	return len(b)
+}
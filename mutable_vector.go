@@ -1,5 +1,7 @@
 package vector
 
+import "math"
+
 // MutableVector is a vector where all arithmetic operations will be done in
 // place on the calling vector. This will increase performance and minimize the
 // memory consumption.
@@ -126,3 +128,147 @@ func (a MutableVector) Z() float64 {
 
 	return a[z]
 }
+
+// W is corresponding to doing a MutableVector[3] lookup, if index 3 does not exist yet, a
+// 0 will be returned instead
+func (a MutableVector) W() float64 {
+	if len(a) < 4 {
+		return 0
+	}
+
+	return a[w]
+}
+
+// Get is corresponding to doing a MutableVector[i] lookup, if index i does not exist yet, a
+// 0 will be returned instead
+func (a MutableVector) Get(i int) float64 {
+	return component(a, i)
+}
+
+// Set the component at index i to v in place. If index i does not exist yet
+// the vector grows with zeros to fit it, which like append can move the vector
+// to new memory, so the result must be used instead of the receiver:
+//
+//	v, err = v.Set(5, 1)
+//
+// It returns ErrNotValidIndex for a negative index.
+func (a MutableVector) Set(i int, v float64) (MutableVector, error) {
+	if i < 0 {
+		return a, ErrNotValidIndex
+	}
+
+	return set(a, i, v), nil
+}
+
+// SetX sets the first component, growing the vector like Set when it does not
+// exist yet
+func (a MutableVector) SetX(v float64) MutableVector {
+	return set(a, x, v)
+}
+
+// SetY sets the second component, growing the vector like Set when it does not
+// exist yet
+func (a MutableVector) SetY(v float64) MutableVector {
+	return set(a, y, v)
+}
+
+// SetZ sets the third component, growing the vector like Set when it does not
+// exist yet
+func (a MutableVector) SetZ(v float64) MutableVector {
+	return set(a, z, v)
+}
+
+// SetW sets the fourth component, growing the vector like Set when it does not
+// exist yet
+func (a MutableVector) SetW(v float64) MutableVector {
+	return set(a, w, v)
+}
+
+// Resize the vector to n dimensions, where extra dimensions are cut and missing
+// dimensions are filled with zeros, and a negative n gives an empty vector.
+// Like Set the result must be used instead of the receiver, as growing the
+// vector can move it to new memory.
+func (a MutableVector) Resize(n int) MutableVector {
+	if n < 0 {
+		n = 0
+	}
+	return grow(a, n)
+}
+
+// Mul multiplies the vector componentwise with another vector, which is the
+// Hadamard product. Components missing from b are left unchanged.
+func (a MutableVector) Mul(b Vector) MutableVector {
+	return mul(a, b)
+}
+
+// Div divides the vector componentwise by another vector. Components missing
+// from b are left unchanged.
+func (a MutableVector) Div(b Vector) MutableVector {
+	return div(a, b)
+}
+
+// Min returns the componentwise minimum of two vectors. Components missing
+// from b are left unchanged.
+func (a MutableVector) Min(b Vector) MutableVector {
+	return minimum(a, b)
+}
+
+// Max returns the componentwise maximum of two vectors. Components missing
+// from b are left unchanged.
+func (a MutableVector) Max(b Vector) MutableVector {
+	return maximum(a, b)
+}
+
+// Clamp limits every component to the range between the components of min and
+// max. Components missing from min or max are not limited by it.
+func (a MutableVector) Clamp(min, max Vector) MutableVector {
+	return clamp(a, min, max)
+}
+
+// Abs returns the absolute value of every component
+func (a MutableVector) Abs() MutableVector {
+	return apply(a, math.Abs)
+}
+
+// Floor rounds every component down to the closest integer
+func (a MutableVector) Floor() MutableVector {
+	return apply(a, math.Floor)
+}
+
+// Ceil rounds every component up to the closest integer
+func (a MutableVector) Ceil() MutableVector {
+	return apply(a, math.Ceil)
+}
+
+// Round rounds every component to the closest integer, with halves rounded
+// away from zero
+func (a MutableVector) Round() MutableVector {
+	return apply(a, math.Round)
+}
+
+// Sqrt returns the square root of every component
+func (a MutableVector) Sqrt() MutableVector {
+	return apply(a, math.Sqrt)
+}
+
+// Pow raises every component to the given power
+func (a MutableVector) Pow(p float64) MutableVector {
+	return pow(a, p)
+}
+
+// Map calls f on every component and stores the results in place
+func (a MutableVector) Map(f func(float64) float64) MutableVector {
+	return apply(a, f)
+}
+
+// Apply calls f on every component and the matching component of b, and stores the results in place.
+// Components missing from b are left unchanged.
+func (a MutableVector) Apply(b Vector, f func(a, b float64) float64) MutableVector {
+	return zip(a, b, f)
+}
+
+// Reduce folds the components into a single value, by calling f with the
+// result so far, starting at initial, and every component in order
+func (a MutableVector) Reduce(f func(accumulator, v float64) float64, initial float64) float64 {
+	return reduce(a, f, initial)
+}
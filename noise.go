@@ -0,0 +1,446 @@
+package vector
+
+import (
+	"math"
+	"math/rand"
+)
+
+// MaxNoiseDimension is the highest dimension of the noise functions, any extra
+// components of a vector are ignored and get a zero gradient
+const MaxNoiseDimension = 4
+
+// NoiseField is a smooth pseudo random scalar field, which is the same for the
+// same seed and point
+type NoiseField interface {
+	// At returns the value of the noise at the point
+	At(p Vector) float64
+	// Gradient returns the analytic derivative of the noise at the point, with
+	// the same dimension as the point
+	Gradient(p Vector) Vector
+}
+
+// lattice holds the seed and the table of unit gradients that gradient noise
+// picks from for every point of the integer lattice
+type lattice struct {
+	seed      uint64
+	gradients [MaxNoiseDimension][256]Vector
+}
+
+func newLattice(seed int64) lattice {
+	l := lattice{seed: uint64(seed)}
+	s := NewSampler(rand.NewSource(seed))
+
+	for d := range l.gradients {
+		for i := range l.gradients[d] {
+			l.gradients[d][i] = s.OnSphere(d + 1)
+		}
+	}
+
+	return l
+}
+
+// gradient returns the gradient of the lattice point
+func (l *lattice) gradient(point []int) Vector {
+	return l.gradients[len(point)-1][hash(l.seed, point, 0)&255]
+}
+
+// Perlin is the improved gradient noise by Ken Perlin, with values between -1
+// and 1 that are 0 at every integer point
+type Perlin struct {
+	lattice
+}
+
+// NewPerlin returns Perlin noise for the seed
+func NewPerlin(seed int64) *Perlin {
+	return &Perlin{newLattice(seed)}
+}
+
+// At returns the value of the noise at the point
+func (n *Perlin) At(p Vector) float64 {
+	v, _ := n.eval(p, false)
+	return v
+}
+
+// Gradient returns the analytic derivative of the noise at the point
+func (n *Perlin) Gradient(p Vector) Vector {
+	_, g := n.eval(p, true)
+	return g
+}
+
+func (n *Perlin) eval(p Vector, derivative bool) (float64, Vector) {
+	dim := noiseDimension(p)
+
+	var gradient Vector
+	if derivative {
+		gradient = make(Vector, len(p))
+	}
+
+	if dim == 0 {
+		return 0, gradient
+	}
+
+	var cell, corner [MaxNoiseDimension]int
+	var f, u, du, d [MaxNoiseDimension]float64
+	for i := 0; i < dim; i++ {
+		floor := math.Floor(p[i])
+		cell[i], f[i] = int(floor), p[i]-floor
+		u[i], du[i] = fade(f[i])
+	}
+
+	// the noise is the dot products of the corner gradients with the offset
+	// to each corner, blended by the fade curve of every axis
+	var value float64
+	for c := 0; c < 1<<uint(dim); c++ {
+		for i := 0; i < dim; i++ {
+			bit := c >> uint(i) & 1
+			corner[i], d[i] = cell[i]+bit, f[i]-float64(bit)
+		}
+
+		g := n.gradient(corner[:dim])
+		dot := 0.
+		for i := 0; i < dim; i++ {
+			dot += g[i] * d[i]
+		}
+
+		value += dot * perlinWeight(c, dim, u, -1)
+
+		if derivative {
+			for j := 0; j < dim; j++ {
+				dw := -du[j]
+				if c>>uint(j)&1 == 1 {
+					dw = du[j]
+				}
+				gradient[j] += g[j]*perlinWeight(c, dim, u, -1) + dot*dw*perlinWeight(c, dim, u, j)
+			}
+		}
+	}
+
+	// unit gradients give values within half the square root of the dimension
+	s := 2 / math.Sqrt(float64(dim))
+	return value * s, scale(gradient, s)
+}
+
+// perlinWeight returns the product of the fade weights of the corner along
+// every axis except the skipped one
+func perlinWeight(corner, dim int, u [MaxNoiseDimension]float64, skip int) float64 {
+	w := 1.
+	for i := 0; i < dim; i++ {
+		if i == skip {
+			continue
+		}
+
+		if corner>>uint(i)&1 == 1 {
+			w *= u[i]
+		} else {
+			w *= 1 - u[i]
+		}
+	}
+	return w
+}
+
+// fade returns the quintic fade curve 6t⁵ - 15t⁴ + 10t³ and its derivative
+func fade(t float64) (float64, float64) {
+	return t * t * t * (t*(t*6-15) + 10), 30 * t * t * (t*(t-2) + 1)
+}
+
+// simplexScale scales the simplex noise of each dimension to values between -1
+// and 1, a little below the largest values found by sampling
+var simplexScale = [MaxNoiseDimension]float64{70, 96, 100, 100}
+
+// Simplex is the simplex noise by Ken Perlin, which sums the gradients of the
+// corners of a simplex instead of a hypercube and so scales better with the
+// dimension. Its values are between -1 and 1.
+type Simplex struct {
+	lattice
+}
+
+// NewSimplex returns simplex noise for the seed
+func NewSimplex(seed int64) *Simplex {
+	return &Simplex{newLattice(seed)}
+}
+
+// At returns the value of the noise at the point
+func (n *Simplex) At(p Vector) float64 {
+	v, _ := n.eval(p, false)
+	return v
+}
+
+// Gradient returns the analytic derivative of the noise at the point
+func (n *Simplex) Gradient(p Vector) Vector {
+	_, g := n.eval(p, true)
+	return g
+}
+
+func (n *Simplex) eval(p Vector, derivative bool) (float64, Vector) {
+	dim := noiseDimension(p)
+
+	var gradient Vector
+	if derivative {
+		gradient = make(Vector, len(p))
+	}
+
+	if dim == 0 {
+		return 0, gradient
+	}
+
+	// skew the point onto the lattice of hypercubes that are each split into
+	// simplices, and unskew the cell back to find the offset into it
+	k := float64(dim)
+	skew, unskew := (math.Sqrt(k+1)-1)/k, (1-1/math.Sqrt(k+1))/k
+
+	s, t := 0., 0.
+	for i := 0; i < dim; i++ {
+		s += p[i]
+	}
+	s *= skew
+
+	var cell, corner, order [MaxNoiseDimension]int
+	var x0, d [MaxNoiseDimension]float64
+	for i := 0; i < dim; i++ {
+		cell[i] = int(math.Floor(p[i] + s))
+		t += float64(cell[i])
+	}
+	t *= unskew
+
+	for i := 0; i < dim; i++ {
+		x0[i] = p[i] - float64(cell[i]) + t
+		order[i] = i
+	}
+
+	// the simplex holding the point is found by stepping along the axes in the
+	// order of the largest offset first
+	for i := 1; i < dim; i++ {
+		for j := i; j > 0 && x0[order[j]] > x0[order[j-1]]; j-- {
+			order[j], order[j-1] = order[j-1], order[j]
+		}
+	}
+
+	corner = cell
+	var value float64
+	for c := 0; c <= dim; c++ {
+		if c > 0 {
+			corner[order[c-1]]++
+		}
+
+		r := 0.5
+		for i := 0; i < dim; i++ {
+			d[i] = x0[i] - float64(corner[i]-cell[i]) + float64(c)*unskew
+			r -= d[i] * d[i]
+		}
+
+		if r <= 0 {
+			continue
+		}
+
+		g := n.gradient(corner[:dim])
+		dot := 0.
+		for i := 0; i < dim; i++ {
+			dot += g[i] * d[i]
+		}
+
+		r2 := r * r
+		value += r2 * r2 * dot
+
+		if derivative {
+			for i := 0; i < dim; i++ {
+				gradient[i] += r2*r2*g[i] - 8*r2*r*dot*d[i]
+			}
+		}
+	}
+
+	return value * simplexScale[dim-1], scale(gradient, simplexScale[dim-1])
+}
+
+// Worley is the cellular noise by Steven Worley, which is the distance to the
+// closest of a set of random feature points, one in every cell of the integer
+// lattice.
+//
+// Like most implementations only the neighbouring cells are searched, which in
+// rare cases misses a closer feature point two cells away.
+type Worley struct {
+	seed uint64
+}
+
+// NewWorley returns Worley noise for the seed
+func NewWorley(seed int64) *Worley {
+	return &Worley{seed: uint64(seed)}
+}
+
+// At returns the distance to the closest feature point
+func (n *Worley) At(p Vector) float64 {
+	f1, _, _ := n.search(p)
+	return f1
+}
+
+// Gradient returns the analytic derivative of the distance to the closest
+// feature point, which is the unit vector pointing away from it
+func (n *Worley) Gradient(p Vector) Vector {
+	f1, _, closest := n.search(p)
+	gradient := make(Vector, len(p))
+
+	if f1 == 0 {
+		return gradient
+	}
+
+	for i := 0; i < noiseDimension(p); i++ {
+		gradient[i] = (p[i] - closest[i]) / f1
+	}
+
+	return gradient
+}
+
+// Distances returns the distance to the closest and the second closest feature
+// point, often combined as F2 - F1 to give the edges of the cells
+func (n *Worley) Distances(p Vector) (float64, float64) {
+	f1, f2, _ := n.search(p)
+	return f1, f2
+}
+
+func (n *Worley) search(p Vector) (float64, float64, [MaxNoiseDimension]float64) {
+	dim := noiseDimension(p)
+	f1, f2 := math.Inf(1), math.Inf(1)
+	var closest [MaxNoiseDimension]float64
+
+	if dim == 0 {
+		return 0, 0, closest
+	}
+
+	var cell, neighbour [MaxNoiseDimension]int
+	for i := 0; i < dim; i++ {
+		cell[i] = int(math.Floor(p[i]))
+	}
+
+	count := 1
+	for i := 0; i < dim; i++ {
+		count *= 3
+	}
+
+	for c := 0; c < count; c++ {
+		var feature [MaxNoiseDimension]float64
+		distance := 0.
+
+		for i, rest := 0, c; i < dim; i, rest = i+1, rest/3 {
+			neighbour[i] = cell[i] + rest%3 - 1
+		}
+
+		for i := 0; i < dim; i++ {
+			h := hash(n.seed, neighbour[:dim], i+1)
+			feature[i] = float64(neighbour[i]) + float64(h>>11)/(1<<53)
+			distance += (p[i] - feature[i]) * (p[i] - feature[i])
+		}
+
+		if distance < f1 {
+			f1, f2, closest = distance, f1, feature
+		} else if distance < f2 {
+			f2 = distance
+		}
+	}
+
+	return math.Sqrt(f1), math.Sqrt(f2), closest
+}
+
+func noiseDimension(p Vector) int {
+	if len(p) > MaxNoiseDimension {
+		return MaxNoiseDimension
+	}
+	return len(p)
+}
+
+// hash mixes the seed, the lattice point and a salt into a pseudo random
+// number with the splitmix64 finalizer
+func hash(seed uint64, point []int, salt int) uint64 {
+	h := seed
+	for _, c := range point {
+		h = mix(h ^ uint64(c))
+	}
+	return mix(h ^ uint64(salt))
+}
+
+func mix(z uint64) uint64 {
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// Fractal sums octaves of a noise at increasing frequencies and decreasing
+// amplitudes, which is fractal Brownian motion, or turbulence when the
+// absolute values of the octaves are summed. The result is normalized by the
+// sum of the amplitudes, so it keeps the range of the noise.
+type Fractal struct {
+	Noise NoiseField
+	// Octaves is the number of layers of noise, by default 6
+	Octaves int
+	// Lacunarity is the factor the frequency grows by every octave, by
+	// default 2
+	Lacunarity float64
+	// Gain is the factor the amplitude shrinks by every octave, by default
+	// 0.5
+	Gain float64
+	// Turbulence sums the absolute values of the octaves, which gives sharp
+	// creases where the noise crosses zero
+	Turbulence bool
+}
+
+func (f Fractal) withDefaults() Fractal {
+	if f.Octaves <= 0 {
+		f.Octaves = 6
+	}
+
+	if f.Lacunarity == 0 {
+		f.Lacunarity = 2
+	}
+
+	if f.Gain == 0 {
+		f.Gain = 0.5
+	}
+
+	return f
+}
+
+// At returns the value of the fractal noise at the point
+func (f Fractal) At(p Vector) float64 {
+	f = f.withDefaults()
+	frequency, amplitude, total, value := 1., 1., 0., 0.
+
+	for octave := 0; octave < f.Octaves; octave++ {
+		v := f.Noise.At(scale(clone(p), frequency))
+		if f.Turbulence {
+			v = math.Abs(v)
+		}
+
+		value += amplitude * v
+		total += amplitude
+		frequency *= f.Lacunarity
+		amplitude *= f.Gain
+	}
+
+	return value / total
+}
+
+// Gradient returns the analytic derivative of the fractal noise at the point,
+// which for turbulence is undefined where an octave crosses zero
+func (f Fractal) Gradient(p Vector) Vector {
+	f = f.withDefaults()
+	frequency, amplitude, total := 1., 1., 0.
+	gradient := make(Vector, len(p))
+
+	for octave := 0; octave < f.Octaves; octave++ {
+		q := scale(clone(p), frequency)
+		g := f.Noise.Gradient(q)
+
+		// the chain rule brings out the frequency, and the sign of the octave
+		// for the absolute value of turbulence
+		s := amplitude * frequency
+		if f.Turbulence && f.Noise.At(q) < 0 {
+			s = -s
+		}
+
+		axpyUnitaryTo(gradient, s, g, gradient)
+		total += amplitude
+		frequency *= f.Lacunarity
+		amplitude *= f.Gain
+	}
+
+	return scale(gradient, 1/total)
+}
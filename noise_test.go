@@ -0,0 +1,181 @@
+package vector_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func noiseFields(seed int64) map[string]vector.NoiseField {
+	return map[string]vector.NoiseField{
+		"perlin":     vector.NewPerlin(seed),
+		"simplex":    vector.NewSimplex(seed),
+		"worley":     vector.NewWorley(seed),
+		"fbm":        vector.Fractal{Noise: vector.NewSimplex(seed), Octaves: 4},
+		"turbulence": vector.Fractal{Noise: vector.NewPerlin(seed), Octaves: 3, Turbulence: true},
+	}
+}
+
+func TestNoiseSeeding(t *testing.T) {
+	a, b, c := noiseFields(1), noiseFields(1), noiseFields(2)
+	p := vec{1.3, -2.7, 0.4}
+
+	for name := range a {
+		if a[name].At(p) != b[name].At(p) {
+			t.Errorf("expected %v noise with the same seed to be equal", name)
+		}
+
+		if a[name].At(p) == c[name].At(p) {
+			t.Errorf("expected %v noise with another seed to differ", name)
+		}
+	}
+}
+
+func TestNoiseRange(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for dim := 2; dim <= 4; dim++ {
+		for name, n := range noiseFields(1) {
+			for i := 0; i < 2000; i++ {
+				p := make(vec, dim)
+				for k := range p {
+					p[k] = r.Float64()*20 - 10
+				}
+
+				v := n.At(p)
+				if name == "worley" {
+					if v < 0 || v > math.Sqrt(float64(dim)) {
+						t.Fatalf("%v noise at %v is %v", name, p, v)
+					}
+				} else if v < -1 || v > 1 {
+					t.Fatalf("%v noise at %v is %v", name, p, v)
+				}
+			}
+		}
+	}
+}
+
+func TestNoiseGradient(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const h = 1e-6
+
+	for dim := 2; dim <= 4; dim++ {
+		for name, n := range noiseFields(1) {
+			for i := 0; i < 100; i++ {
+				p := make(vec, dim)
+				for k := range p {
+					p[k] = r.Float64()*20 - 10
+				}
+
+				g := n.Gradient(p)
+				if len(g) != dim {
+					t.Fatalf("expected a %v dimensional gradient, got %v", dim, g)
+				}
+
+				for k := range p {
+					forward, backward := p.Clone(), p.Clone()
+					forward[k] += h
+					backward[k] -= h
+					numeric := (n.At(forward) - n.At(backward)) / (2 * h)
+
+					// worley noise and turbulence have creases, where the central
+					// difference straddles two sides
+					if math.Abs(numeric-g[k]) > 1e-4*(1+math.Abs(numeric)) && name != "worley" && name != "turbulence" {
+						t.Errorf("%v gradient at %v is %v, expected %v in component %v", name, p, g, numeric, k)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestNoiseCreasedGradient(t *testing.T) {
+	// away from the creases the gradients of worley noise and turbulence are
+	// still exact, so most of them match the central difference
+	r := rand.New(rand.NewSource(1))
+	const h = 1e-7
+
+	for _, name := range []string{"worley", "turbulence"} {
+		n, matches := noiseFields(1)[name], 0
+
+		for i := 0; i < 200; i++ {
+			p := vec{r.Float64() * 10, r.Float64() * 10, r.Float64() * 10}
+			g := n.Gradient(p)
+			forward := p.Add(vec{h, 0, 0})
+			if numeric := (n.At(forward) - n.At(p)) / h; math.Abs(numeric-g[0]) < 1e-4 {
+				matches++
+			}
+		}
+
+		if matches < 190 {
+			t.Errorf("expected most %v gradients to match, got %v of 200", name, matches)
+		}
+	}
+}
+
+func TestPerlin(t *testing.T) {
+	n := vector.NewPerlin(1)
+
+	for _, p := range []vec{{0, 0}, {3, -2}, {1, 2, 3}, {-4, 0, 7, 1}} {
+		if v := n.At(p); v != 0 {
+			t.Errorf("expected perlin noise at the lattice point %v to be 0, got %v", p, v)
+		}
+	}
+
+	// components beyond the highest dimension are ignored
+	if n.At(vec{0.1, 0.2, 0.3, 0.4, 5}) != n.At(vec{0.1, 0.2, 0.3, 0.4}) {
+		t.Error("expected extra components to be ignored")
+	}
+
+	if g := n.Gradient(vec{0.1, 0.2, 0.3, 0.4, 5}); len(g) != 5 || g[4] != 0 {
+		t.Errorf("expected a zero gradient for extra components, got %v", g)
+	}
+}
+
+func TestWorley(t *testing.T) {
+	n := vector.NewWorley(1)
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		p := vec{r.Float64() * 10, r.Float64() * 10}
+		f1, f2 := n.Distances(p)
+
+		if f1 != n.At(p) || f1 > f2 {
+			t.Fatalf("unexpected distances %v and %v at %v", f1, f2, p)
+		}
+
+		// stepping against the gradient moves straight to the feature point
+		if g := n.Gradient(p); math.Abs(g.Magnitude()-1) > 1e-9 || n.At(p.Sub(g.Scale(f1))) > 1e-9 {
+			t.Fatalf("gradient %v at %v does not point away from the feature point", g, p)
+		}
+	}
+}
+
+func TestFractal(t *testing.T) {
+	n := vector.NewSimplex(1)
+	p := vec{0.3, 0.7, 1.1}
+
+	if single := (vector.Fractal{Noise: n, Octaves: 1}); single.At(p) != n.At(p) {
+		t.Error("expected a single octave to be the noise itself")
+	}
+
+	defaults := vector.Fractal{Noise: n}
+	explicit := vector.Fractal{Noise: n, Octaves: 6, Lacunarity: 2, Gain: 0.5}
+	if defaults.At(p) != explicit.At(p) {
+		t.Error("expected the zero value to use the default settings")
+	}
+
+	if turbulence := (vector.Fractal{Noise: n, Turbulence: true}); turbulence.At(p) < 0 {
+		t.Error("expected turbulence to be positive")
+	}
+}
+
+func BenchmarkSimplex3D(b *testing.B) {
+	n := vector.NewSimplex(1)
+	p := vec{0.3, 0.7, 1.1}
+	for i := 0; i < b.N; i++ {
+		n.At(p)
+	}
+}
@@ -0,0 +1,212 @@
+package vector
+
+import "math"
+
+// PCA is a principal component analysis that reduces vectors to a lower
+// dimensional space spanned by the directions of the largest variance.
+//
+// It can either be fitted on a full dataset with Fit, or incrementally on
+// batches with PartialFit, where the memory used only depends on the batch
+// size and never on a full dim×dim covariance matrix.
+type PCA struct {
+	k          int
+	stats      *Accumulator
+	components []Vector
+	singular   Vector
+	variance   Vector
+}
+
+// NewPCA returns a principal component analysis that reduces vectors to k
+// dimensions
+func NewPCA(k int) *PCA {
+	return &PCA{k: k}
+}
+
+// Fit the principal component analysis on a dataset, any previous fit will
+// be discarded
+func (p *PCA) Fit(data []Vector) error {
+	if len(data) == 0 {
+		return ErrEmptyDataset
+	}
+
+	dim := len(data[0])
+	p.stats = NewCovarianceAccumulator(dim)
+	for _, v := range data {
+		p.stats.Push(v)
+	}
+
+	values, vectors, err := Eigen(p.stats.Covariance())
+	if err != nil {
+		return err
+	}
+
+	k := p.size(dim)
+	p.components, p.variance = vectors[:k], values[:k]
+	p.singular = make(Vector, k)
+
+	for i := range p.variance {
+		p.variance[i] = math.Max(0, p.variance[i])
+		p.singular[i] = math.Sqrt(p.variance[i] * float64(len(data)-1))
+	}
+
+	return nil
+}
+
+// PartialFit updates the principal component analysis with a batch of vectors
+// using the incremental algorithm by Ross et al. Calling PartialFit with all
+// batches of a dataset gives the same components as calling Fit with the full
+// dataset, up to the sign of the components.
+func (p *PCA) PartialFit(batch []Vector) error {
+	if len(batch) == 0 {
+		return ErrEmptyDataset
+	}
+
+	if p.stats == nil {
+		p.stats = NewAccumulator(len(batch[0]))
+	}
+
+	dim, seen := len(p.stats.mean), float64(p.stats.Count())
+	batchMean := Mean(batch)
+	oldMean := p.stats.Mean()
+
+	for _, v := range batch {
+		p.stats.Push(v)
+	}
+
+	// stack the previous components scaled by their singular values, the
+	// centered batch and a correction for the shift of the mean into a single
+	// matrix whose right singular vectors are the updated components
+	x := make([]Vector, 0, len(p.components)+len(batch)+1)
+
+	for i := range p.components {
+		x = append(x, scale(clone(p.components[i]), p.singular[i]))
+	}
+
+	for _, v := range batch {
+		x = append(x, centered(v, batchMean, dim))
+	}
+
+	if seen > 0 {
+		total := seen + float64(len(batch))
+		correction := sub(oldMean, resize(batchMean, dim))
+		x = append(x, scale(correction, math.Sqrt(seen/total*float64(len(batch)))))
+	}
+
+	_, s, v, err := SVD(x)
+	if err != nil {
+		return err
+	}
+
+	k := p.size(dim)
+	if k > len(s) {
+		k = len(s)
+	}
+
+	p.components, p.singular = v[:k], s[:k]
+	p.variance = make(Vector, k)
+
+	if n := float64(p.stats.Count()); n > 1 {
+		for i := range p.variance {
+			p.variance[i] = p.singular[i] * p.singular[i] / (n - 1)
+		}
+	}
+
+	return nil
+}
+
+// Components returns the principal components sorted by their explained
+// variance in descending order
+func (p *PCA) Components() []Vector {
+	return cloneMatrix(p.components)
+}
+
+// ExplainedVariance returns the variance of the data along each of the
+// principal components
+func (p *PCA) ExplainedVariance() Vector {
+	return clone(p.variance)
+}
+
+// ExplainedVarianceRatio returns the fraction of the total variance of the data
+// that each of the principal components explains
+func (p *PCA) ExplainedVarianceRatio() Vector {
+	if p.stats == nil {
+		return Vector{}
+	}
+
+	var total float64
+	for _, v := range p.stats.Variance() {
+		total += v
+	}
+
+	ratio := clone(p.variance)
+	if total == 0 {
+		return ratio
+	}
+
+	return scale(ratio, 1/total)
+}
+
+// Mean returns the mean of the data the principal component analysis was fitted
+// on
+func (p *PCA) Mean() Vector {
+	if p.stats == nil {
+		return Vector{}
+	}
+
+	return p.stats.Mean()
+}
+
+// Transform projects a vector onto the principal components
+func (p *PCA) Transform(v Vector) Vector {
+	result := make(Vector, len(p.components))
+
+	if p.stats == nil {
+		return result
+	}
+
+	c := centered(v, p.stats.mean, len(p.stats.mean))
+	for i := range p.components {
+		result[i] = dot(p.components[i], c)
+	}
+
+	return result
+}
+
+// InverseTransform maps a vector from the reduced space back into the input
+// space
+func (p *PCA) InverseTransform(v Vector) Vector {
+	if p.stats == nil {
+		return Vector{}
+	}
+
+	result := p.stats.Mean()
+	for i := range p.components {
+		if i < len(v) {
+			axpyUnitaryTo(result, v[i], p.components[i], result)
+		}
+	}
+
+	return result
+}
+
+// size returns the number of components for the given input dimension
+func (p *PCA) size(dim int) int {
+	if p.k <= 0 || p.k > dim {
+		return dim
+	}
+
+	return p.k
+}
+
+// centered returns a copy of v, resized to dim, with the mean subtracted
+func centered(v, mean Vector, dim int) Vector {
+	return sub(resize(v, dim), mean)
+}
+
+// resize returns a copy of the vector with the given dimension, either cutting
+// the extra components or padding it with zeros
+func resize(a []float64, dim int) []float64 {
+	result := make([]float64, dim)
+	copy(result, a)
+	return result
+}
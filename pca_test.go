@@ -0,0 +1,99 @@
+package vector_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func correlatedData(n int) []vec {
+	r := rand.New(rand.NewSource(1))
+	data := make([]vec, n)
+	for i := range data {
+		a, b := r.NormFloat64()*5, r.NormFloat64()
+		data[i] = vec{a + 1, a + b + 2, 0.1*r.NormFloat64() - 3, b}
+	}
+	return data
+}
+
+func TestPCA(t *testing.T) {
+	data := correlatedData(200)
+	pca := vector.NewPCA(2)
+
+	if err := pca.Fit(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pca.Components()) != 2 || len(pca.Transform(data[0])) != 2 {
+		t.Error("did not reduce to 2 dimensions")
+	}
+
+	if !pca.Mean().Equal(vector.Mean(data)) {
+		t.Error("mean of the pca differs from the mean of the data")
+	}
+
+	var ratio float64
+	for _, r := range pca.ExplainedVarianceRatio() {
+		ratio += r
+	}
+
+	if ratio < 0.99 {
+		t.Errorf("expected 2 components to explain most of the variance, got %v", ratio)
+	}
+
+	for _, v := range data[:10] {
+		if v.Sub(pca.InverseTransform(pca.Transform(v))).Magnitude() > 0.5 {
+			t.Errorf("reconstruction of %v is too far off", v)
+		}
+	}
+}
+
+func TestPCARoundTrip(t *testing.T) {
+	data := correlatedData(50)
+	pca := vector.NewPCA(0)
+
+	if err := pca.Fit(data); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range data {
+		if !pca.InverseTransform(pca.Transform(v)).Equal(v) {
+			t.Errorf("round trip with all components did not return %v", v)
+		}
+	}
+}
+
+func TestIncrementalPCA(t *testing.T) {
+	data := correlatedData(300)
+	full, incremental := vector.NewPCA(2), vector.NewPCA(2)
+
+	if err := full.Fit(data); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < len(data); i += 25 {
+		if err := incremental.PartialFit(data[i : i+25]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !full.Mean().Equal(incremental.Mean()) {
+		t.Error("incremental mean differs from full mean")
+	}
+
+	for i, c := range full.Components() {
+		if math.Abs(math.Abs(c.Dot(incremental.Components()[i]))-1) > 1e-6 {
+			t.Errorf("component %v differs, expected %v got %v", i, c, incremental.Components()[i])
+		}
+
+		if math.Abs(full.ExplainedVariance()[i]-incremental.ExplainedVariance()[i]) > 1e-6 {
+			t.Errorf("explained variance %v differs", i)
+		}
+	}
+
+	if err := vector.NewPCA(1).Fit(nil); err != vector.ErrEmptyDataset {
+		t.Error("did not return ErrEmptyDataset for an empty dataset")
+	}
+}
@@ -0,0 +1,307 @@
+package vector
+
+import (
+	"math"
+	"sort"
+)
+
+// Polyline is a list of points connected by straight line segments. Distances
+// along the polyline are measured from the first point and clamped to the
+// length of the polyline.
+type Polyline []Vector
+
+// Length returns the total length of the polyline
+func (p Polyline) Length() float64 {
+	var result float64
+	for i := 1; i < len(p); i++ {
+		result += Euclidean(p[i-1], p[i])
+	}
+	return result
+}
+
+// PointAt returns the point at the given distance along the polyline
+func (p Polyline) PointAt(distance float64) Vector {
+	i, u := p.locate(distance)
+	if i < 0 {
+		return nil
+	}
+
+	if i == len(p)-1 {
+		return clone(p[i])
+	}
+
+	return lerp(resize(p[i], len(p[0])), p[i+1], u)
+}
+
+// TangentAt returns the unit direction of the segment at the given distance
+// along the polyline
+func (p Polyline) TangentAt(distance float64) Vector {
+	i, _ := p.locate(distance)
+	if i < 0 {
+		return nil
+	}
+
+	if i == len(p)-1 {
+		i--
+	}
+
+	if i < 0 {
+		return make(Vector, len(p[0]))
+	}
+
+	return unit(sub(resize(p[i+1], len(p[0])), p[i]))
+}
+
+// Resample returns n points evenly spaced along the polyline, including the
+// first and the last point
+func (p Polyline) Resample(n int) Polyline {
+	if len(p) == 0 || n <= 0 {
+		return Polyline{}
+	}
+
+	if n == 1 {
+		return Polyline{clone(p[0])}
+	}
+
+	return p.resample(p.Length()/float64(n-1), n)
+}
+
+// ResampleSpacing returns points spaced the given distance apart along the
+// polyline, starting with the first point. The last point is included if the
+// length of the polyline is not a multiple of the spacing.
+func (p Polyline) ResampleSpacing(spacing float64) Polyline {
+	if len(p) == 0 || spacing <= 0 {
+		return Polyline{}
+	}
+
+	length := p.Length()
+	n := int(math.Floor(length/spacing+1e-9)) + 1
+	result := p.resample(spacing, n)
+
+	if last := p[len(p)-1]; Euclidean(result[len(result)-1], last) > 1e-9 {
+		result = append(result, clone(last))
+	}
+
+	return result
+}
+
+// resample walks the polyline once, placing n points spacing apart
+func (p Polyline) resample(spacing float64, n int) Polyline {
+	result := make(Polyline, 0, n)
+	i, start := 0, 0.
+
+	for k := 0; k < n; k++ {
+		target := float64(k) * spacing
+
+		for i < len(p)-1 {
+			l := Euclidean(p[i], p[i+1])
+			if start+l >= target {
+				break
+			}
+			start += l
+			i++
+		}
+
+		if i == len(p)-1 {
+			result = append(result, clone(p[i]))
+			continue
+		}
+
+		u := 0.
+		if l := Euclidean(p[i], p[i+1]); l > 0 {
+			u = math.Min(1, (target-start)/l)
+		}
+		result = append(result, lerp(resize(p[i], len(p[0])), p[i+1], u))
+	}
+
+	return result
+}
+
+// ClosestPoint returns the point on the polyline closest to the given point,
+// and its distance along the polyline
+func (p Polyline) ClosestPoint(point Vector) (Vector, float64) {
+	if len(p) == 0 {
+		return nil, 0
+	}
+
+	if len(p) == 1 {
+		return clone(p[0]), 0
+	}
+
+	var closest Vector
+	best, along, start := math.Inf(1), 0., 0.
+
+	for i := 1; i < len(p); i++ {
+		a, b := resize(p[i-1], len(p[0])), resize(p[i], len(p[0]))
+		ab, ap := sub(clone(b), a), sub(resize(point, len(a)), a)
+		l := dot(ab, ab)
+
+		u := 0.
+		if l > 0 {
+			u = math.Max(0, math.Min(1, dot(ap, ab)/l))
+		}
+
+		c := lerp(a, b, u)
+		if d := squaredEuclidean(c, point); d < best {
+			best, closest, along = d, c, start+u*math.Sqrt(l)
+		}
+
+		start += math.Sqrt(l)
+	}
+
+	return closest, along
+}
+
+// locate returns the index of the segment at the given distance along the
+// polyline and the fraction along that segment. It returns -1 for an empty
+// polyline and the index of the last point beyond its end.
+func (p Polyline) locate(distance float64) (int, float64) {
+	if len(p) == 0 {
+		return -1, 0
+	}
+
+	if distance <= 0 {
+		return 0, 0
+	}
+
+	for i := 1; i < len(p); i++ {
+		l := Euclidean(p[i-1], p[i])
+		if distance <= l && l > 0 {
+			return i - 1, distance / l
+		}
+		distance -= l
+	}
+
+	return len(p) - 1, 0
+}
+
+// gaussLegendre holds the nodes and weights of the 5-point Gauss-Legendre
+// quadrature on the interval -1 to 1
+var gaussLegendre = [5][2]float64{
+	{0, 128. / 225},
+	{-0.5384693101056831, 0.47862867049936647},
+	{0.5384693101056831, 0.47862867049936647},
+	{-0.906179845938664, 0.23692688505618908},
+	{0.906179845938664, 0.23692688505618908},
+}
+
+// ArcLength is an arc-length table for a curve, used to move along the curve by
+// distance rather than by its parameter. The length of each segment of the
+// table is integrated with Gaussian quadrature.
+type ArcLength struct {
+	curve  Curve
+	params Vector
+	length Vector
+}
+
+// NewArcLength builds an arc-length table for the curve, with the parameter
+// range split into the given number of segments. More segments give a more
+// accurate table for curves where the speed changes a lot.
+func NewArcLength(c Curve, segments int) *ArcLength {
+	if segments < 1 {
+		segments = 1
+	}
+
+	a := &ArcLength{
+		curve:  c,
+		params: make(Vector, segments+1),
+		length: make(Vector, segments+1),
+	}
+
+	for i := 1; i <= segments; i++ {
+		a.params[i] = float64(i) / float64(segments)
+		a.length[i] = a.length[i-1] + a.integrate(a.params[i-1], a.params[i])
+	}
+
+	return a
+}
+
+// Length returns the total length of the curve
+func (a *ArcLength) Length() float64 {
+	return a.length[len(a.length)-1]
+}
+
+// Parameter returns the parameter t of the curve at the given distance along
+// it, by looking up the segment in the table and refining the parameter with
+// Newton's method
+func (a *ArcLength) Parameter(distance float64) float64 {
+	if distance <= 0 {
+		return 0
+	}
+
+	if distance >= a.Length() {
+		return 1
+	}
+
+	i := sort.SearchFloat64s(a.length, distance) - 1
+	lo, hi := a.params[i], a.params[i+1]
+
+	// start from the linear interpolation within the segment and keep the
+	// bracket lo to hi, so a bad Newton step can fall back to bisection
+	t := lo + (hi-lo)*(distance-a.length[i])/(a.length[i+1]-a.length[i])
+
+	for iteration := 0; iteration < 20; iteration++ {
+		f := a.length[i] + a.integrate(a.params[i], t) - distance
+
+		if math.Abs(f) < 1e-12 {
+			break
+		}
+
+		if f > 0 {
+			hi = t
+		} else {
+			lo = t
+		}
+
+		speed := magnitude(a.curve.Derivative(t))
+		next := t - f/speed
+
+		if speed == 0 || next <= lo || next >= hi {
+			next = (lo + hi) / 2
+		}
+
+		t = next
+	}
+
+	return t
+}
+
+// PointAt returns the point at the given distance along the curve
+func (a *ArcLength) PointAt(distance float64) Vector {
+	return a.curve.At(a.Parameter(distance))
+}
+
+// TangentAt returns the unit tangent at the given distance along the curve
+func (a *ArcLength) TangentAt(distance float64) Vector {
+	return unit(a.curve.Derivative(a.Parameter(distance)))
+}
+
+// Resample returns n points evenly spaced by distance along the curve
+func (a *ArcLength) Resample(n int) Polyline {
+	if n <= 0 {
+		return Polyline{}
+	}
+
+	if n == 1 {
+		return Polyline{a.curve.At(0)}
+	}
+
+	result := make(Polyline, n)
+	for i := range result {
+		result[i] = a.PointAt(a.Length() * float64(i) / float64(n-1))
+	}
+
+	return result
+}
+
+// integrate returns the length of the curve from t0 to t1
+func (a *ArcLength) integrate(t0, t1 float64) float64 {
+	half, mid := (t1-t0)/2, (t0+t1)/2
+
+	var result float64
+	for _, node := range gaussLegendre {
+		result += node[1] * magnitude(a.curve.Derivative(mid+half*node[0]))
+	}
+
+	return result * half
+}
@@ -0,0 +1,87 @@
+package vector_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func TestPolyline(t *testing.T) {
+	p := vector.Polyline{{0, 0}, {3, 0}, {3, 4}}
+
+	if p.Length() != 7 {
+		t.Errorf("expected length 7, got %v", p.Length())
+	}
+
+	for distance, expected := range map[float64]vec{-1: {0, 0}, 1.5: {1.5, 0}, 3: {3, 0}, 5: {3, 2}, 10: {3, 4}} {
+		if !p.PointAt(distance).Equal(expected) {
+			t.Errorf("point at %v is %v, expected %v", distance, p.PointAt(distance), expected)
+		}
+	}
+
+	if !p.TangentAt(1).Equal(vec{1, 0}) || !p.TangentAt(6).Equal(vec{0, 1}) || !p.TangentAt(7).Equal(vec{0, 1}) {
+		t.Error("unexpected tangents")
+	}
+
+	point, along := p.ClosestPoint(vec{5, 1})
+	if !point.Equal(vec{3, 1}) || along != 4 {
+		t.Errorf("closest point is %v at %v, expected [3 1] at 4", point, along)
+	}
+}
+
+func TestPolylineResample(t *testing.T) {
+	p := vector.Polyline{{0, 0}, {3, 0}, {3, 4}}
+
+	resampled := p.Resample(8)
+	if len(resampled) != 8 {
+		t.Fatalf("expected 8 points, got %v", len(resampled))
+	}
+
+	for i, v := range resampled {
+		if !v.Equal(p.PointAt(float64(i))) {
+			t.Errorf("resampled point %v is %v, expected %v", i, v, p.PointAt(float64(i)))
+		}
+	}
+
+	spaced := p.ResampleSpacing(2)
+	if len(spaced) != 5 || !spaced[3].Equal(vec{3, 3}) || !spaced[4].Equal(vec{3, 4}) {
+		t.Errorf("unexpected resampling by spacing %v", spaced)
+	}
+}
+
+func TestArcLength(t *testing.T) {
+	// a quarter circle approximated by a cubic Bézier curve, which has a close
+	// to constant speed
+	k := 4 * (math.Sqrt2 - 1) / 3
+	b := vector.Bezier{{1, 0}, {1, k}, {k, 1}, {0, 1}}
+	table := vector.NewArcLength(b, 16)
+
+	if math.Abs(table.Length()-math.Pi/2) > 1e-3 {
+		t.Errorf("expected length close to π/2, got %v", table.Length())
+	}
+
+	line := vector.Bezier{{0, 0}, {0.1, 0}, {9, 0}, {10, 0}}
+	table = vector.NewArcLength(line, 8)
+
+	if math.Abs(table.Length()-10) > 1e-9 {
+		t.Errorf("expected length 10, got %v", table.Length())
+	}
+
+	for _, d := range []float64{0, 1, 2.5, 7, 10} {
+		if !table.PointAt(d).Equal(vec{d, 0}) {
+			t.Errorf("point at %v is %v", d, table.PointAt(d))
+		}
+	}
+
+	resampled := table.Resample(11)
+	for i := 1; i < len(resampled); i++ {
+		if math.Abs(resampled[i].Sub(resampled[i-1]).Magnitude()-1) > 1e-9 {
+			t.Errorf("resampled points %v and %v are not evenly spaced", resampled[i-1], resampled[i])
+		}
+	}
+
+	if !table.TangentAt(5).Equal(vec{1, 0}) {
+		t.Errorf("unexpected tangent %v", table.TangentAt(5))
+	}
+}
@@ -0,0 +1,295 @@
+package vector
+
+import "math"
+
+// Uint8Quantizer compresses vectors to one byte per component, by mapping the
+// range of every component linearly onto the codes 0 to 255. A code c of
+// component i decodes to Min[i] + c*Step[i].
+type Uint8Quantizer struct {
+	Min  Vector
+	Step Vector
+}
+
+// NewUint8Quantizer returns a quantizer fitted to the range of the dataset,
+// either per dimension or one global range for all components. It returns
+// ErrEmptyDataset if the dataset has no vectors.
+func NewUint8Quantizer(data []Vector, perDimension bool) (*Uint8Quantizer, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyDataset
+	}
+
+	min, max := quantizerRange(data, perDimension)
+	q := &Uint8Quantizer{Min: min, Step: make(Vector, len(min))}
+	for i := range min {
+		q.Step[i] = (max[i] - min[i]) / 255
+	}
+
+	return q, nil
+}
+
+// Encode returns the codes of a vector, where values outside of the fitted
+// range are clamped to it
+func (q *Uint8Quantizer) Encode(v Vector) []uint8 {
+	code := make([]uint8, len(q.Min))
+	for i := range code {
+		code[i] = uint8(quantize(component(v, i)-q.Min[i], q.Step[i], 0, 255))
+	}
+	return code
+}
+
+// Decode returns the vector approximated by the codes
+func (q *Uint8Quantizer) Decode(code []uint8) Vector {
+	result := make(Vector, len(code))
+	for i, c := range code {
+		result[i] = q.Min[i] + float64(c)*q.Step[i]
+	}
+	return result
+}
+
+// Dot returns the dot product of a query vector and the vector approximated by
+// the codes, without decoding them first
+func (q *Uint8Quantizer) Dot(query Vector, code []uint8) float64 {
+	var result float64
+	for i, c := range code {
+		result += component(query, i) * (q.Min[i] + float64(c)*q.Step[i])
+	}
+	return result
+}
+
+// SquaredDistance returns the squared euclidean distance between a query vector
+// and the vector approximated by the codes, without decoding them first
+func (q *Uint8Quantizer) SquaredDistance(query Vector, code []uint8) float64 {
+	var result float64
+	for i, c := range code {
+		d := component(query, i) - q.Min[i] - float64(c)*q.Step[i]
+		result += d * d
+	}
+	return result
+}
+
+// Int8Quantizer compresses vectors to one byte per component, by mapping the
+// largest absolute value of every component onto the codes -127 to 127, so
+// zero stays exactly zero. A code c of component i decodes to c*Scale[i].
+type Int8Quantizer struct {
+	Scale Vector
+}
+
+// NewInt8Quantizer returns a quantizer fitted to the largest absolute values of
+// the dataset, either per dimension or one global value for all components. It
+// returns ErrEmptyDataset if the dataset has no vectors.
+func NewInt8Quantizer(data []Vector, perDimension bool) (*Int8Quantizer, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyDataset
+	}
+
+	min, max := quantizerRange(data, perDimension)
+	q := &Int8Quantizer{Scale: make(Vector, len(min))}
+	for i := range min {
+		q.Scale[i] = math.Max(math.Abs(min[i]), math.Abs(max[i])) / 127
+	}
+
+	return q, nil
+}
+
+// Encode returns the codes of a vector, where values outside of the fitted
+// range are clamped to it
+func (q *Int8Quantizer) Encode(v Vector) []int8 {
+	code := make([]int8, len(q.Scale))
+	for i := range code {
+		code[i] = int8(quantize(component(v, i), q.Scale[i], -127, 127))
+	}
+	return code
+}
+
+// Decode returns the vector approximated by the codes
+func (q *Int8Quantizer) Decode(code []int8) Vector {
+	result := make(Vector, len(code))
+	for i, c := range code {
+		result[i] = float64(c) * q.Scale[i]
+	}
+	return result
+}
+
+// Dot returns the dot product of a query vector and the vector approximated by
+// the codes, without decoding them first
+func (q *Int8Quantizer) Dot(query Vector, code []int8) float64 {
+	var result float64
+	for i, c := range code {
+		result += component(query, i) * float64(c) * q.Scale[i]
+	}
+	return result
+}
+
+// SquaredDistance returns the squared euclidean distance between a query vector
+// and the vector approximated by the codes, without decoding them first
+func (q *Int8Quantizer) SquaredDistance(query Vector, code []int8) float64 {
+	var result float64
+	for i, c := range code {
+		d := component(query, i) - float64(c)*q.Scale[i]
+		result += d * d
+	}
+	return result
+}
+
+// quantizerRange returns the componentwise min and max of the dataset, or the
+// global min and max repeated for every component
+func quantizerRange(data []Vector, perDimension bool) (Vector, Vector) {
+	min, max := Min(data), Max(data)
+
+	if !perDimension {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for i := range min {
+			lo, hi = math.Min(lo, min[i]), math.Max(hi, max[i])
+		}
+
+		for i := range min {
+			min[i], max[i] = lo, hi
+		}
+	}
+
+	return min, max
+}
+
+// quantize returns the value divided by the step, rounded and clamped to the
+// codes from lo to hi
+func quantize(value, step, lo, hi float64) float64 {
+	if step == 0 {
+		return math.Max(lo, math.Min(hi, 0))
+	}
+	return math.Max(lo, math.Min(hi, math.Round(value/step)))
+}
+
+// ProductQuantizer compresses vectors by splitting them into subspaces and
+// storing, for every subspace, the index of the closest centroid of a codebook
+// trained with k-means. With at most 256 centroids every subspace takes a
+// single byte.
+type ProductQuantizer struct {
+	// Codebooks holds the centroids of every subspace
+	Codebooks [][]Vector
+	// bounds holds where every subspace starts, followed by the dimension
+	bounds []int
+}
+
+// NewProductQuantizer trains a product quantizer on the dataset, with the
+// dimension of the first vector split into the given number of subspaces of
+// nearly equal size, and a codebook of the given number of centroids for each.
+// The options are passed on to KMeans.
+//
+// It returns ErrNotValidDimension if the number of subspaces is not between 1
+// and the dimension, and ErrNotValidClusterCount if the number of centroids is
+// not between 1 and 256 or larger than the dataset.
+func NewProductQuantizer(data []Vector, subspaces, centroids int, opts ClusterOptions) (*ProductQuantizer, error) {
+	if len(data) == 0 {
+		return nil, ErrEmptyDataset
+	}
+
+	dim := len(data[0])
+	if subspaces < 1 || subspaces > dim {
+		return nil, ErrNotValidDimension
+	}
+
+	if centroids > 256 {
+		return nil, ErrNotValidClusterCount
+	}
+
+	q := &ProductQuantizer{Codebooks: make([][]Vector, subspaces), bounds: make([]int, subspaces+1)}
+	for s := 0; s < subspaces; s++ {
+		q.bounds[s+1] = q.bounds[s] + dim/subspaces
+		if s < dim%subspaces {
+			q.bounds[s+1]++
+		}
+	}
+
+	full, part := make([]Vector, len(data)), make([]Vector, len(data))
+	for i, v := range data {
+		full[i] = resize(v, dim)
+	}
+
+	for s := range q.Codebooks {
+		for i, v := range full {
+			part[i] = v[q.bounds[s]:q.bounds[s+1]]
+		}
+
+		c, err := KMeans(part, centroids, opts)
+		if err != nil {
+			return nil, err
+		}
+		q.Codebooks[s] = c.Centroids
+	}
+
+	return q, nil
+}
+
+// Encode returns the index of the closest centroid in every subspace
+func (q *ProductQuantizer) Encode(v Vector) []uint8 {
+	v = resize(v, q.bounds[len(q.bounds)-1])
+	code := make([]uint8, len(q.Codebooks))
+
+	for s, codebook := range q.Codebooks {
+		part, best := v[q.bounds[s]:q.bounds[s+1]], math.Inf(1)
+		for c, centroid := range codebook {
+			if d := squaredEuclidean(part, centroid); d < best {
+				best, code[s] = d, uint8(c)
+			}
+		}
+	}
+
+	return code
+}
+
+// Decode returns the vector approximated by the codes, which is the centroids
+// of all subspaces joined together
+func (q *ProductQuantizer) Decode(code []uint8) Vector {
+	result := make(Vector, 0, q.bounds[len(q.bounds)-1])
+	for s, c := range code {
+		result = append(result, q.Codebooks[s][c]...)
+	}
+	return result
+}
+
+// DistanceTable holds the distances from a query vector to every centroid of a
+// product quantizer, so the asymmetric distance to a code is a sum of table
+// lookups
+type DistanceTable struct {
+	squared [][]float64
+	dot     [][]float64
+}
+
+// Table returns the distance table of a query vector, which is computed once
+// and used for all codes compared to the query
+func (q *ProductQuantizer) Table(query Vector) *DistanceTable {
+	query = resize(query, q.bounds[len(q.bounds)-1])
+	t := &DistanceTable{squared: make([][]float64, len(q.Codebooks)), dot: make([][]float64, len(q.Codebooks))}
+
+	for s, codebook := range q.Codebooks {
+		part := query[q.bounds[s]:q.bounds[s+1]]
+		t.squared[s], t.dot[s] = make([]float64, len(codebook)), make([]float64, len(codebook))
+
+		for c, centroid := range codebook {
+			t.squared[s][c] = squaredEuclidean(part, centroid)
+			t.dot[s][c] = dot(part, centroid)
+		}
+	}
+
+	return t
+}
+
+// SquaredDistance returns the squared euclidean distance between the query and
+// the vector approximated by the codes
+func (t *DistanceTable) SquaredDistance(code []uint8) float64 {
+	var result float64
+	for s, c := range code {
+		result += t.squared[s][c]
+	}
+	return result
+}
+
+// Dot returns the dot product of the query and the vector approximated by the
+// codes
+func (t *DistanceTable) Dot(code []uint8) float64 {
+	var result float64
+	for s, c := range code {
+		result += t.dot[s][c]
+	}
+	return result
+}
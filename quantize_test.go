@@ -0,0 +1,234 @@
+package vector_test
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+// embeddings returns vectors scattered around a number of random centers,
+// which is closer to real embeddings than uniform noise
+func embeddings(r *rand.Rand, n, dim int) []vec {
+	centers := make([]vec, 16)
+	for i := range centers {
+		centers[i] = make(vec, dim)
+		for k := range centers[i] {
+			centers[i][k] = r.NormFloat64()
+		}
+	}
+
+	data := make([]vec, n)
+	for i := range data {
+		c := centers[r.Intn(len(centers))]
+		data[i] = make(vec, dim)
+		for k := range data[i] {
+			data[i][k] = c[k] + 0.5*r.NormFloat64()
+		}
+	}
+
+	return data
+}
+
+// top returns the indices of the k largest scores
+func top(scores []float64, k int) []int {
+	indices := make([]int, len(scores))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	sort.Slice(indices, func(i, j int) bool {
+		return scores[indices[i]] > scores[indices[j]]
+	})
+
+	return indices[:k]
+}
+
+// recall returns the fraction of the exact top k dot products of the queries
+// found within the top n of the approximate scores
+func recall(data, queries []vec, k, n int, approximate func(q vec) []float64) float64 {
+	found := 0
+
+	for _, q := range queries {
+		exact, scores := make([]float64, len(data)), approximate(q)
+		for i, v := range data {
+			exact[i] = q.Dot(v)
+		}
+
+		candidates := map[int]bool{}
+		for _, i := range top(scores, n) {
+			candidates[i] = true
+		}
+
+		for _, i := range top(exact, k) {
+			if candidates[i] {
+				found++
+			}
+		}
+	}
+
+	return float64(found) / float64(k*len(queries))
+}
+
+func TestUint8Quantizer(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	data := embeddings(r, 1000, 32)
+
+	for _, perDimension := range []bool{true, false} {
+		q, err := vector.NewUint8Quantizer(data, perDimension)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		codes := make([][]uint8, len(data))
+		for i, v := range data {
+			codes[i] = q.Encode(v)
+
+			// rounding to the closest code is off by at most half a step
+			for k, c := range q.Decode(codes[i]) {
+				if math.Abs(c-v[k]) > q.Step[k]/2+1e-12 {
+					t.Fatalf("component %v of %v decoded to %v", k, v[k], c)
+				}
+			}
+		}
+
+		query := data[0].Scale(0.5)
+		if d := q.Dot(query, codes[1]); math.Abs(d-query.Dot(q.Decode(codes[1]))) > 1e-9 {
+			t.Errorf("expected the dot product of the decoded vector, got %v", d)
+		}
+
+		if d := q.SquaredDistance(query, codes[1]); math.Abs(d-math.Pow(vector.Euclidean(query, q.Decode(codes[1])), 2)) > 1e-9 {
+			t.Errorf("expected the squared distance to the decoded vector, got %v", d)
+		}
+
+		queries := embeddings(r, 20, 32)
+		if rc := recall(data, queries, 10, 10, func(query vec) []float64 {
+			scores := make([]float64, len(codes))
+			for i, c := range codes {
+				scores[i] = q.Dot(query, c)
+			}
+			return scores
+		}); rc < 0.9 {
+			t.Errorf("expected a recall@10 of at least 0.9, got %v", rc)
+		}
+	}
+
+	if _, err := vector.NewUint8Quantizer(nil, true); err != vector.ErrEmptyDataset {
+		t.Errorf("expected ErrEmptyDataset, got %v", err)
+	}
+}
+
+func TestInt8Quantizer(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	data := embeddings(r, 1000, 32)
+
+	for _, perDimension := range []bool{true, false} {
+		q, err := vector.NewInt8Quantizer(data, perDimension)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if code := q.Encode(make(vec, 32)); !q.Decode(code).Equal(make(vec, 32)) {
+			t.Error("expected zero to stay exactly zero")
+		}
+
+		// values outside of the fitted range are clamped
+		if code := q.Encode(data[0].Scale(1000)); code[0] != 127 && code[0] != -127 {
+			t.Errorf("expected a clamped code, got %v", code[0])
+		}
+
+		codes := make([][]int8, len(data))
+		for i, v := range data {
+			codes[i] = q.Encode(v)
+		}
+
+		query := data[0].Scale(0.5)
+		if d := q.SquaredDistance(query, codes[1]); math.Abs(d-math.Pow(vector.Euclidean(query, q.Decode(codes[1])), 2)) > 1e-9 {
+			t.Errorf("expected the squared distance to the decoded vector, got %v", d)
+		}
+
+		queries := embeddings(r, 20, 32)
+		if rc := recall(data, queries, 10, 10, func(query vec) []float64 {
+			scores := make([]float64, len(codes))
+			for i, c := range codes {
+				scores[i] = q.Dot(query, c)
+			}
+			return scores
+		}); rc < 0.9 {
+			t.Errorf("expected a recall@10 of at least 0.9, got %v", rc)
+		}
+	}
+}
+
+func TestProductQuantizer(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	data := embeddings(r, 2000, 32)
+
+	q, err := vector.NewProductQuantizer(data, 8, 256, vector.ClusterOptions{MaxIterations: 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	codes := make([][]uint8, len(data))
+	for i, v := range data {
+		codes[i] = q.Encode(v)
+		if len(codes[i]) != 8 {
+			t.Fatalf("expected a code of 8 bytes, got %v", len(codes[i]))
+		}
+	}
+
+	query := data[0].Scale(0.5)
+	table := q.Table(query)
+	decoded := q.Decode(codes[1])
+
+	if d := table.Dot(codes[1]); math.Abs(d-query.Dot(decoded)) > 1e-9 {
+		t.Errorf("expected the dot product of the decoded vector %v, got %v", query.Dot(decoded), d)
+	}
+
+	if d := table.SquaredDistance(codes[1]); math.Abs(d-math.Pow(vector.Euclidean(query, decoded), 2)) > 1e-9 {
+		t.Errorf("expected the squared distance to the decoded vector, got %v", d)
+	}
+
+	queries := embeddings(r, 20, 32)
+	rc := recall(data, queries, 10, 50, func(query vec) []float64 {
+		table, scores := q.Table(query), make([]float64, len(codes))
+		for i, c := range codes {
+			scores[i] = table.Dot(c)
+		}
+		return scores
+	})
+
+	if rc < 0.9 {
+		t.Errorf("expected a recall 10@50 of at least 0.9, got %v", rc)
+	}
+}
+
+func TestProductQuantizerValidation(t *testing.T) {
+	data := []vec{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}
+
+	if _, err := vector.NewProductQuantizer(data, 4, 2, vector.ClusterOptions{}); err != vector.ErrNotValidDimension {
+		t.Errorf("expected ErrNotValidDimension, got %v", err)
+	}
+
+	if _, err := vector.NewProductQuantizer(data, 2, 300, vector.ClusterOptions{}); err != vector.ErrNotValidClusterCount {
+		t.Errorf("expected ErrNotValidClusterCount, got %v", err)
+	}
+
+	// the subspaces are split as evenly as possible
+	q, err := vector.NewProductQuantizer(data, 2, 3, vector.ClusterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(q.Codebooks[0][0]) != 2 || len(q.Codebooks[1][0]) != 1 {
+		t.Errorf("expected subspaces of 2 and 1 dimensions, got %v", q.Codebooks)
+	}
+
+	for _, v := range data {
+		if !q.Decode(q.Encode(v)).Equal(v) {
+			t.Errorf("expected %v to be encoded exactly with a centroid per vector", v)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package vector
+
+import "math"
+
+// Quaternion is a rotation in 3-dimensions stored as Quaternion{x, y, z, w},
+// where x, y and z is the vector part and w is the scalar part. The zero
+// rotation is Quaternion{0, 0, 0, 1}.
+type Quaternion Vector
+
+// FromAxisAngle returns the quaternion rotating the given angle in radians
+// around the axis
+func FromAxisAngle(axis Vector, angle float64) Quaternion {
+	u := unit(resize(axis, 3))
+	sin, cos := math.Sincos(angle / 2)
+	return Quaternion{u[x] * sin, u[y] * sin, u[z] * sin, cos}
+}
+
+// Mul returns the product of two quaternions, which is the rotation b followed
+// by the rotation a
+func (a Quaternion) Mul(b Quaternion) Quaternion {
+	return Quaternion{
+		a[3]*b[0] + a[0]*b[3] + a[1]*b[2] - a[2]*b[1],
+		a[3]*b[1] - a[0]*b[2] + a[1]*b[3] + a[2]*b[0],
+		a[3]*b[2] + a[0]*b[1] - a[1]*b[0] + a[2]*b[3],
+		a[3]*b[3] - a[0]*b[0] - a[1]*b[1] - a[2]*b[2],
+	}
+}
+
+// Conjugate returns the quaternion with the vector part negated, which for a
+// unit quaternion is the inverse rotation
+func (a Quaternion) Conjugate() Quaternion {
+	return Quaternion{-a[0], -a[1], -a[2], a[3]}
+}
+
+// Unit returns the quaternion with the length of one
+func (a Quaternion) Unit() Quaternion {
+	return unit(clone(a))
+}
+
+// Rotate returns the vector rotated by the unit quaternion
+func (a Quaternion) Rotate(v Vector) Vector {
+	p := Quaternion{v.X(), v.Y(), v.Z(), 0}
+	r := a.Mul(p).Mul(a.Conjugate())
+	return Vector{r[0], r[1], r[2]}
+}
+
+// Matrix returns the 3×3 rotation matrix of the unit quaternion as a list of
+// rows
+func (a Quaternion) Matrix() []Vector {
+	qx, qy, qz, qw := a[0], a[1], a[2], a[3]
+	return []Vector{
+		{1 - 2*(qy*qy+qz*qz), 2 * (qx*qy - qz*qw), 2 * (qx*qz + qy*qw)},
+		{2 * (qx*qy + qz*qw), 1 - 2*(qx*qx+qz*qz), 2 * (qy*qz - qx*qw)},
+		{2 * (qx*qz - qy*qw), 2 * (qy*qz + qx*qw), 1 - 2*(qx*qx+qy*qy)},
+	}
+}
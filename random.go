@@ -0,0 +1,388 @@
+package vector
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Sampler generates random vectors from a caller supplied source, so the
+// sequence of vectors is reproducible by seeding the source.
+type Sampler struct {
+	r *rand.Rand
+}
+
+// NewSampler returns a sampler drawing its random numbers from the source
+func NewSampler(src rand.Source) *Sampler {
+	return &Sampler{r: rand.New(src)}
+}
+
+// InBox returns a vector uniformly distributed in the box spanned by the
+// componentwise min and max
+func (s *Sampler) InBox(min, max Vector) Vector {
+	result := make(Vector, len(min))
+	for i := range result {
+		result[i] = min[i] + s.r.Float64()*(component(max, i)-min[i])
+	}
+	return result
+}
+
+// OnSphere returns a vector uniformly distributed on the surface of the unit
+// sphere in the given dimension
+func (s *Sampler) OnSphere(dim int) Vector {
+	if dim <= 0 {
+		return Vector{}
+	}
+
+	for {
+		result := s.normal(dim)
+		if l := magnitude(result); l > 1e-12 {
+			return scale(result, 1/l)
+		}
+	}
+}
+
+// InBall returns a vector uniformly distributed inside the unit ball in the
+// given dimension
+func (s *Sampler) InBall(dim int) Vector {
+	r := math.Pow(s.r.Float64(), 1/float64(dim))
+	return scale(s.OnSphere(dim), r)
+}
+
+// InDisk returns a 2-dimensional vector uniformly distributed inside the unit
+// disk
+func (s *Sampler) InDisk() Vector {
+	r, theta := math.Sqrt(s.r.Float64()), 2*math.Pi*s.r.Float64()
+	return Vector{r * math.Cos(theta), r * math.Sin(theta)}
+}
+
+// InTriangle returns a vector uniformly distributed inside the triangle with
+// the corners a, b and c, which can be of any dimension
+func (s *Sampler) InTriangle(a, b, c Vector) Vector {
+	u, v := s.r.Float64(), s.r.Float64()
+
+	// reflect the samples that land in the other half of the parallelogram
+	if u+v > 1 {
+		u, v = 1-u, 1-v
+	}
+
+	result := resize(a, len(a))
+	axpyUnitaryTo(result, u, sub(resize(b, len(a)), a), result)
+	axpyUnitaryTo(result, v, sub(resize(c, len(a)), a), result)
+	return result
+}
+
+// CosineHemisphere returns a 3-dimensional unit vector on the hemisphere around
+// the normal, distributed with a density proportional to the cosine of the
+// angle to the normal
+func (s *Sampler) CosineHemisphere(normal Vector) Vector {
+	d := s.InDisk()
+	local := Vector{d[x], d[y], math.Sqrt(math.Max(0, 1-d[x]*d[x]-d[y]*d[y]))}
+
+	// rotate the sample from around the z axis to around the normal
+	n := Vector(unit(resize(normal, 3)))
+	if n.Equal(Z) {
+		return local
+	}
+
+	if n.Equal(Z.Invert()) {
+		return local.Invert()
+	}
+
+	axis := cross3(Z, n)
+	return local.Rotate(math.Acos(n[z]), axis)
+}
+
+// Gaussian returns a vector from the multivariate normal distribution with the
+// given mean and covariance matrix. It returns ErrNotPositiveDefinite if the
+// covariance matrix is not positive definite.
+//
+// The covariance matrix is factorized on every call, use GaussianCholesky with
+// the result of Cholesky when drawing many vectors from the same distribution.
+func (s *Sampler) Gaussian(mean Vector, covariance []Vector) (Vector, error) {
+	l, err := Cholesky(covariance)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GaussianCholesky(mean, l), nil
+}
+
+// GaussianCholesky returns a vector from the multivariate normal distribution
+// with the given mean and the lower triangular Cholesky factor of the
+// covariance matrix
+func (s *Sampler) GaussianCholesky(mean Vector, l []Vector) Vector {
+	z := s.normal(len(l))
+	result := resize(mean, len(l))
+	for i := range l {
+		for j := 0; j <= i; j++ {
+			result[i] += l[i][j] * z[j]
+		}
+	}
+	return result
+}
+
+// PoissonDisk returns points in the box spanned by the componentwise min and
+// max, where no two points are closer than the radius, using Bridson's
+// algorithm. It is meant for 2 and 3 dimensions but works for any dimension,
+// though the size of its background grid grows exponentially with it.
+func (s *Sampler) PoissonDisk(min, max Vector, radius float64) []Vector {
+	dim := len(min)
+	if dim == 0 || radius <= 0 {
+		return []Vector{}
+	}
+
+	cell := radius / math.Sqrt(float64(dim))
+	size, cells := make([]int, dim), 1
+	for i := range size {
+		size[i] = int(math.Ceil((component(max, i)-min[i])/cell)) + 1
+		cells *= size[i]
+	}
+
+	grid := make([]int, cells)
+	for i := range grid {
+		grid[i] = -1
+	}
+
+	index := func(p Vector) int {
+		result := 0
+		for i := dim - 1; i >= 0; i-- {
+			result = result*size[i] + int((p[i]-min[i])/cell)
+		}
+		return result
+	}
+
+	// reach is the number of cells in each direction that can hold a point
+	// within the radius
+	reach := int(math.Ceil(math.Sqrt(float64(dim))))
+	offset := make([]int, dim)
+
+	fits := func(p Vector, points []Vector) bool {
+		for i := range p {
+			if p[i] < min[i] || p[i] > component(max, i) {
+				return false
+			}
+		}
+
+		for i := range offset {
+			offset[i] = -reach
+		}
+
+		for {
+			neighbour, inside := 0, true
+			for i := dim - 1; i >= 0; i-- {
+				c := int((p[i]-min[i])/cell) + offset[i]
+				if c < 0 || c >= size[i] {
+					inside = false
+					break
+				}
+				neighbour = neighbour*size[i] + c
+			}
+
+			if inside {
+				if j := grid[neighbour]; j >= 0 && squaredEuclidean(points[j], p) < radius*radius {
+					return false
+				}
+			}
+
+			// step to the next offset like an odometer
+			i := 0
+			for ; i < dim; i++ {
+				if offset[i]++; offset[i] <= reach {
+					break
+				}
+				offset[i] = -reach
+			}
+
+			if i == dim {
+				return true
+			}
+		}
+	}
+
+	first := s.InBox(min, max)
+	points, active := []Vector{first}, []int{0}
+	grid[index(first)] = 0
+
+	for len(active) > 0 {
+		k := s.r.Intn(len(active))
+		center, found := points[active[k]], false
+
+		for attempt := 0; attempt < 30; attempt++ {
+			candidate := scale(s.OnSphere(dim), radius*(1+s.r.Float64()))
+			add(candidate, center)
+
+			if fits(candidate, points) {
+				grid[index(candidate)] = len(points)
+				active = append(active, len(points))
+				points = append(points, candidate)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			active[k] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+
+	return points
+}
+
+func (s *Sampler) normal(dim int) Vector {
+	result := make(Vector, dim)
+	for i := range result {
+		result[i] = s.r.NormFloat64()
+	}
+	return result
+}
+
+// Cholesky returns the lower triangular matrix L, as a list of rows, where
+// L * Lᵀ equals the symmetric positive definite matrix. It returns
+// ErrNotPositiveDefinite if the matrix is not positive definite.
+func Cholesky(m []Vector) ([]Vector, error) {
+	n := len(m)
+	for i := range m {
+		if len(m[i]) != n {
+			return nil, ErrNotSquareMatrix
+		}
+	}
+
+	l := zeros(n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			sum := m[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+
+			if i == j {
+				if sum <= 0 {
+					return nil, ErrNotPositiveDefinite
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+
+	return l, nil
+}
+
+// Halton returns the point with the given index from the Halton sequence in
+// the given dimension, using the first primes as bases. The sequence starts at
+// index 1, as index 0 is the origin.
+func Halton(index, dim int) Vector {
+	result := make(Vector, dim)
+	for i, base := range primes(dim) {
+		f, r := 1., 0.
+		for n := index; n > 0; n /= base {
+			f /= float64(base)
+			r += f * float64(n%base)
+		}
+		result[i] = r
+	}
+	return result
+}
+
+func primes(n int) []int {
+	result := make([]int, 0, n)
+	for candidate := 2; len(result) < n; candidate++ {
+		prime := true
+		for _, p := range result {
+			if p*p > candidate {
+				break
+			}
+			if candidate%p == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+// sobolDirections holds the degree s, the coefficients a and the initial
+// direction numbers m of the primitive polynomials for the Sobol dimensions
+// after the first, from the new-joe-kuo-6.21201 table by Joe and Kuo
+var sobolDirections = []struct {
+	s, a int
+	m    []uint32
+}{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 1}},
+	{3, 2, []uint32{1, 1, 1}},
+	{4, 1, []uint32{1, 1, 3, 3}},
+	{4, 4, []uint32{1, 3, 5, 13}},
+	{5, 2, []uint32{1, 1, 5, 5, 17}},
+	{5, 4, []uint32{1, 1, 5, 5, 5}},
+	{5, 7, []uint32{1, 1, 7, 11, 19}},
+}
+
+// MaxSobolDimension is the highest dimension supported by Sobol
+const MaxSobolDimension = 10
+
+// Sobol generates the Sobol low-discrepancy sequence, using Gray code order so
+// every next point only needs a single xor per dimension
+type Sobol struct {
+	index      uint32
+	directions [][32]uint32
+	state      []uint32
+}
+
+// NewSobol returns a Sobol sequence generator for the given dimension, which
+// can be at most MaxSobolDimension. It returns ErrNotValidDimension otherwise.
+func NewSobol(dim int) (*Sobol, error) {
+	if dim < 1 || dim > MaxSobolDimension {
+		return nil, ErrNotValidDimension
+	}
+
+	s := &Sobol{directions: make([][32]uint32, dim), state: make([]uint32, dim)}
+
+	for i := 0; i < 32; i++ {
+		s.directions[0][i] = 1 << uint(31-i)
+	}
+
+	for d := 1; d < dim; d++ {
+		p, v := sobolDirections[d-1], &s.directions[d]
+
+		for i := 0; i < p.s; i++ {
+			v[i] = p.m[i] << uint(31-i)
+		}
+
+		for i := p.s; i < 32; i++ {
+			v[i] = v[i-p.s] ^ (v[i-p.s] >> uint(p.s))
+			for k := 1; k < p.s; k++ {
+				v[i] ^= uint32((p.a>>uint(p.s-1-k))&1) * v[i-k]
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// Next returns the next point of the sequence, starting with the origin
+func (s *Sobol) Next() Vector {
+	result := make(Vector, len(s.state))
+	for i := range result {
+		result[i] = float64(s.state[i]) / (1 << 32)
+	}
+
+	// the index of the lowest zero bit decides which direction is xored in
+	c := 0
+	for n := s.index; n&1 == 1; n >>= 1 {
+		c++
+	}
+
+	for i := range s.state {
+		s.state[i] ^= s.directions[i][c]
+	}
+	s.index++
+
+	return result
+}
@@ -0,0 +1,230 @@
+package vector_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func TestSamplerUniform(t *testing.T) {
+	s := vector.NewSampler(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		v := s.InBox(vec{-1, 2, 0}, vec{1, 3, 5})
+		if v[0] < -1 || v[0] > 1 || v[1] < 2 || v[1] > 3 || v[2] < 0 || v[2] > 5 {
+			t.Fatalf("%v is outside of the box", v)
+		}
+
+		if m := s.OnSphere(4).Magnitude(); math.Abs(m-1) > 1e-12 {
+			t.Fatalf("vector on sphere has magnitude %v", m)
+		}
+
+		if m := s.InBall(3).Magnitude(); m > 1 {
+			t.Fatalf("vector in ball has magnitude %v", m)
+		}
+
+		if m := s.InDisk().Magnitude(); m > 1 {
+			t.Fatalf("vector in disk has magnitude %v", m)
+		}
+	}
+
+	// half of the volume of the unit ball in 3-dimensions is within the
+	// radius of the cube root of a half
+	inside, r := 0, math.Cbrt(0.5)
+	for i := 0; i < 10000; i++ {
+		if s.InBall(3).Magnitude() < r {
+			inside++
+		}
+	}
+
+	if inside < 4800 || inside > 5200 {
+		t.Errorf("expected half of the vectors within %v, got %v of 10000", r, inside)
+	}
+}
+
+func TestSamplerReproducible(t *testing.T) {
+	a, b := vector.NewSampler(rand.NewSource(7)), vector.NewSampler(rand.NewSource(7))
+
+	for i := 0; i < 10; i++ {
+		if !a.OnSphere(3).Equal(b.OnSphere(3)) {
+			t.Fatal("expected samplers with the same seed to give the same vectors")
+		}
+	}
+}
+
+func TestSamplerTriangle(t *testing.T) {
+	s := vector.NewSampler(rand.NewSource(1))
+	a, b, c := vec{0, 0, 1}, vec{2, 0, 1}, vec{0, 2, 1}
+
+	sum := make(vec, 3)
+	for i := 0; i < 10000; i++ {
+		v := s.InTriangle(a, b, c)
+		if v[0] < 0 || v[1] < 0 || v[0]+v[1] > 2+1e-12 || v[2] != 1 {
+			t.Fatalf("%v is outside of the triangle", v)
+		}
+		sum = sum.Add(v)
+	}
+
+	// the mean of uniform samples is the centroid of the triangle
+	if mean := sum.Scale(1. / 10000); vector.Euclidean(mean, vec{2. / 3, 2. / 3, 1}) > 0.02 {
+		t.Errorf("expected the mean at the centroid, got %v", mean)
+	}
+}
+
+func TestSamplerCosineHemisphere(t *testing.T) {
+	s := vector.NewSampler(rand.NewSource(1))
+
+	for _, normal := range []vec{{0, 0, 1}, {0, 0, -1}, {1, 1, 0}, {1, -2, 3}} {
+		n := normal.Unit()
+		var cos float64
+
+		for i := 0; i < 10000; i++ {
+			v := s.CosineHemisphere(normal)
+			if math.Abs(v.Magnitude()-1) > 1e-9 || v.Dot(n) < -1e-12 {
+				t.Fatalf("%v is not on the hemisphere around %v", v, normal)
+			}
+			cos += v.Dot(n)
+		}
+
+		// the expected cosine of a cosine weighted hemisphere is 2/3
+		if mean := cos / 10000; math.Abs(mean-2./3) > 0.01 {
+			t.Errorf("expected a mean cosine of 2/3 around %v, got %v", normal, mean)
+		}
+	}
+}
+
+func TestSamplerGaussian(t *testing.T) {
+	s := vector.NewSampler(rand.NewSource(1))
+	mean := vec{1, -2}
+	covariance := []vec{{4, 1.2}, {1.2, 1}}
+
+	samples := make([]vec, 20000)
+	for i := range samples {
+		v, err := s.Gaussian(mean, covariance)
+		if err != nil {
+			t.Fatal(err)
+		}
+		samples[i] = v
+	}
+
+	if m := vector.Mean(samples); vector.Euclidean(m, mean) > 0.05 {
+		t.Errorf("expected mean %v, got %v", mean, m)
+	}
+
+	c := vector.Covariance(samples)
+	for i := range c {
+		for j := range c[i] {
+			if math.Abs(c[i][j]-covariance[i][j]) > 0.1 {
+				t.Errorf("expected covariance %v, got %v", covariance, c)
+			}
+		}
+	}
+
+	if _, err := s.Gaussian(mean, []vec{{1, 2}, {2, 1}}); err != vector.ErrNotPositiveDefinite {
+		t.Errorf("expected ErrNotPositiveDefinite, got %v", err)
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	m := []vec{{4, 12, -16}, {12, 37, -43}, {-16, -43, 98}}
+	l, err := vector.Cholesky(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []vec{{2, 0, 0}, {6, 1, 0}, {-8, 5, 3}}
+	for i := range l {
+		if !l[i].Equal(expected[i]) {
+			t.Errorf("expected %v, got %v", expected, l)
+		}
+	}
+
+	if _, err := vector.Cholesky([]vec{{1, 2}}); err != vector.ErrNotSquareMatrix {
+		t.Errorf("expected ErrNotSquareMatrix, got %v", err)
+	}
+}
+
+func TestSamplerPoissonDisk(t *testing.T) {
+	s := vector.NewSampler(rand.NewSource(1))
+
+	for _, box := range [][2]vec{{{0, 0}, {10, 10}}, {{-2, -2, -2}, {2, 2, 2}}} {
+		radius := 0.5
+		points := s.PoissonDisk(box[0], box[1], radius)
+
+		// a maximal set of points can not leave a gap where a disk of twice the
+		// radius fits, so it must have a reasonable density
+		if len(points) < 100 {
+			t.Errorf("expected more points in %v, got %v", box, len(points))
+		}
+
+		for i := range points {
+			for j := 0; j < i; j++ {
+				if d := vector.Euclidean(points[i], points[j]); d < radius {
+					t.Fatalf("points %v and %v are %v apart", points[i], points[j], d)
+				}
+			}
+
+			for k := range points[i] {
+				if points[i][k] < box[0][k] || points[i][k] > box[1][k] {
+					t.Fatalf("%v is outside of the box", points[i])
+				}
+			}
+		}
+	}
+}
+
+func TestHalton(t *testing.T) {
+	expected := []vec{{0.5, 1. / 3}, {0.25, 2. / 3}, {0.75, 1. / 9}, {0.125, 4. / 9}}
+	for i, e := range expected {
+		if v := vector.Halton(i+1, 2); !v.Equal(e) {
+			t.Errorf("expected point %v to be %v, got %v", i+1, e, v)
+		}
+	}
+}
+
+func TestSobol(t *testing.T) {
+	s, err := vector.NewSobol(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []vec{
+		{0, 0, 0},
+		{0.5, 0.5, 0.5},
+		{0.75, 0.25, 0.25},
+		{0.25, 0.75, 0.75},
+		{0.375, 0.375, 0.625},
+		{0.875, 0.875, 0.125},
+	}
+
+	for i, e := range expected {
+		if v := s.Next(); !v.Equal(e) {
+			t.Errorf("expected point %v to be %v, got %v", i, e, v)
+		}
+	}
+
+	// every dyadic interval of length 1/16 holds exactly one of the first 16
+	// points in each dimension
+	s, _ = vector.NewSobol(vector.MaxSobolDimension)
+	counts := make([][16]int, vector.MaxSobolDimension)
+	for i := 0; i < 16; i++ {
+		for d, c := range s.Next() {
+			counts[d][int(c*16)]++
+		}
+	}
+
+	for d := range counts {
+		for _, c := range counts[d] {
+			if c != 1 {
+				t.Errorf("dimension %v is not stratified, %v", d, counts[d])
+				break
+			}
+		}
+	}
+
+	if _, err := vector.NewSobol(vector.MaxSobolDimension + 1); err != vector.ErrNotValidDimension {
+		t.Errorf("expected ErrNotValidDimension, got %v", err)
+	}
+}
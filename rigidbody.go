@@ -0,0 +1,232 @@
+package vector
+
+import "math"
+
+// RigidBody is a body in 3-dimensions with a position, an orientation, linear
+// and angular velocity, which forces and torques can be applied to. Forces and
+// torques are accumulated until the next call to Integrate.
+//
+// All vectors are in world space, except the inertia tensor which is given in
+// the space of the body.
+type RigidBody struct {
+	Position        Vector
+	Orientation     Quaternion
+	LinearVelocity  Vector
+	AngularVelocity Vector
+
+	inverseMass    float64
+	inverseInertia []Vector
+	force          Vector
+	torque         Vector
+}
+
+// NewRigidBody returns a body at rest in the origin with the given mass and
+// inertia tensor, given as a list of rows. A mass of 0 gives a static body that
+// is not moved by forces or impulses.
+func NewRigidBody(mass float64, inertia []Vector) *RigidBody {
+	b := &RigidBody{
+		Position:        make(Vector, 3),
+		Orientation:     Quaternion{0, 0, 0, 1},
+		LinearVelocity:  make(Vector, 3),
+		AngularVelocity: make(Vector, 3),
+		inverseInertia:  zeros(3, 3),
+		force:           make(Vector, 3),
+		torque:          make(Vector, 3),
+	}
+
+	if mass > 0 {
+		b.inverseMass = 1 / mass
+		b.inverseInertia = inverse3(inertia)
+	}
+
+	return b
+}
+
+// BoxInertia returns the inertia tensor of a solid box with the given mass and
+// the size of the box along the x, y and z axis
+func BoxInertia(mass float64, size Vector) []Vector {
+	w, h, d := size.X(), size.Y(), size.Z()
+	return []Vector{
+		{mass / 12 * (h*h + d*d), 0, 0},
+		{0, mass / 12 * (w*w + d*d), 0},
+		{0, 0, mass / 12 * (w*w + h*h)},
+	}
+}
+
+// SphereInertia returns the inertia tensor of a solid sphere with the given
+// mass and radius
+func SphereInertia(mass, radius float64) []Vector {
+	i := 2. / 5 * mass * radius * radius
+	return []Vector{{i, 0, 0}, {0, i, 0}, {0, 0, i}}
+}
+
+// InverseMass returns the inverse of the mass of the body, which is 0 for
+// static bodies
+func (b *RigidBody) InverseMass() float64 {
+	return b.inverseMass
+}
+
+// ApplyForce applies a force through the center of mass
+func (b *RigidBody) ApplyForce(force Vector) {
+	add(b.force, resize(force, 3))
+}
+
+// ApplyTorque applies a torque around the center of mass
+func (b *RigidBody) ApplyTorque(torque Vector) {
+	add(b.torque, resize(torque, 3))
+}
+
+// ApplyForceAt applies a force at a point in world space, which also gives a
+// torque if the point is not the center of mass
+func (b *RigidBody) ApplyForceAt(force, point Vector) {
+	f := resize(force, 3)
+	add(b.force, f)
+	add(b.torque, cross3(b.arm(point), f))
+}
+
+// ApplyImpulseAt changes the linear and angular velocity instantly by an
+// impulse at a point in world space
+func (b *RigidBody) ApplyImpulseAt(impulse, point Vector) {
+	j := resize(impulse, 3)
+	axpyUnitaryTo(b.LinearVelocity, b.inverseMass, j, b.LinearVelocity)
+	add(b.AngularVelocity, b.inverseInertiaWorld(cross3(b.arm(point), j)))
+}
+
+// VelocityAt returns the velocity of a point in world space that is attached
+// to the body
+func (b *RigidBody) VelocityAt(point Vector) Vector {
+	return add(cross3(b.AngularVelocity, b.arm(point)), b.LinearVelocity)
+}
+
+// Integrate advances the body by the time step h with semi-implicit Euler,
+// using the forces and torques applied since the last step, and then clears
+// them.
+func (b *RigidBody) Integrate(h float64) {
+	axpyUnitaryTo(b.LinearVelocity, h*b.inverseMass, b.force, b.LinearVelocity)
+	axpyUnitaryTo(b.AngularVelocity, h, b.inverseInertiaWorld(b.torque), b.AngularVelocity)
+	axpyUnitaryTo(b.Position, h, b.LinearVelocity, b.Position)
+
+	// the derivative of the orientation is half the angular velocity, as a
+	// quaternion with a zero scalar part, times the orientation
+	w := b.AngularVelocity
+	spin := Quaternion{w[x], w[y], w[z], 0}.Mul(b.Orientation)
+	axpyUnitaryTo(b.Orientation, h/2, spin, b.Orientation)
+	unit(b.Orientation)
+
+	scale(b.force, 0)
+	scale(b.torque, 0)
+}
+
+// arm returns the vector from the center of mass to a point in world space
+func (b *RigidBody) arm(point Vector) Vector {
+	return sub(resize(point, 3), b.Position)
+}
+
+// inverseInertiaWorld multiplies a vector with the inverse inertia tensor
+// rotated into world space, R * I⁻¹ * Rᵀ * v
+func (b *RigidBody) inverseInertiaWorld(v Vector) Vector {
+	local := b.Orientation.Conjugate().Rotate(v)
+	return b.Orientation.Rotate(mulMatrix(b.inverseInertia, local))
+}
+
+// cross3 is the cross product of two 3-dimensional vectors, which all vectors
+// of a rigid body are, so the error of Cross can never happen
+func cross3(a, b Vector) Vector {
+	c, _ := a.Cross(b)
+	return c
+}
+
+// Contact is a point of contact between two bodies, where the normal points
+// from A towards B
+type Contact struct {
+	A, B        *RigidBody
+	Point       Vector
+	Normal      Vector
+	Restitution float64
+	Friction    float64
+}
+
+// Resolve applies the impulses to the two bodies of the contact that stop them
+// from moving into each other, with the restitution as the ratio of the
+// normal velocity kept after the impact, and Coulomb friction along the
+// tangent of the contact. Bodies already moving apart are left untouched.
+func (c Contact) Resolve() {
+	n := unit(resize(c.Normal, 3))
+	relative := Vector(sub(c.B.VelocityAt(c.Point), c.A.VelocityAt(c.Point)))
+	vn := relative.Dot(n)
+
+	if vn >= 0 {
+		return
+	}
+
+	denominator := c.effectiveMass(n)
+	if denominator == 0 {
+		return
+	}
+
+	j := -(1 + c.Restitution) * vn / denominator
+	c.applyImpulse(scale(clone(n), j))
+
+	// the friction is computed from the relative velocity after the normal
+	// impulse, along the direction the bodies slide in
+	relative = Vector(sub(c.B.VelocityAt(c.Point), c.A.VelocityAt(c.Point)))
+	tangent := sub(clone(relative), scale(clone(n), relative.Dot(n)))
+
+	if magnitude(tangent) < 1e-12 {
+		return
+	}
+
+	unit(tangent)
+	jt := -relative.Dot(tangent) / c.effectiveMass(tangent)
+	jt = math.Max(-c.Friction*j, math.Min(c.Friction*j, jt))
+	c.applyImpulse(scale(tangent, jt))
+}
+
+// effectiveMass returns the inverse of the mass the contact has along a
+// direction, taking the rotation of both bodies into account
+func (c Contact) effectiveMass(d Vector) float64 {
+	ra, rb := c.A.arm(c.Point), c.B.arm(c.Point)
+	angularA := cross3(c.A.inverseInertiaWorld(cross3(ra, d)), ra)
+	angularB := cross3(c.B.inverseInertiaWorld(cross3(rb, d)), rb)
+	return c.A.inverseMass + c.B.inverseMass + d.Dot(add(angularA, angularB))
+}
+
+func (c Contact) applyImpulse(j Vector) {
+	c.B.ApplyImpulseAt(j, c.Point)
+	c.A.ApplyImpulseAt(invert(clone(j)), c.Point)
+}
+
+func mulMatrix(m []Vector, v Vector) Vector {
+	result := make(Vector, len(m))
+	for i := range m {
+		result[i] = dot(m[i], v)
+	}
+	return result
+}
+
+// inverse3 returns the inverse of a 3×3 matrix, or a zero matrix if it is
+// singular
+func inverse3(m []Vector) []Vector {
+	a := make([]Vector, 3)
+	for i := range a {
+		a[i] = make(Vector, 3)
+		if i < len(m) {
+			copy(a[i], m[i])
+		}
+	}
+
+	c0, c1, c2 := cross3(a[1], a[2]), cross3(a[2], a[0]), cross3(a[0], a[1])
+	det := a[0].Dot(c0)
+
+	if math.Abs(det) < 1e-300 {
+		return zeros(3, 3)
+	}
+
+	// the rows of the cofactor matrix are the cross products of the other two
+	// rows, and the inverse is its transpose divided by the determinant
+	return transpose([]Vector{
+		scale(c0, 1/det),
+		scale(c1, 1/det),
+		scale(c2, 1/det),
+	})
+}
@@ -0,0 +1,113 @@
+package vector_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func TestQuaternion(t *testing.T) {
+	q := vector.FromAxisAngle(vector.Y, math.Pi/2)
+	v := vec{1, 2, 3}
+
+	if !q.Rotate(v).Equal(v.Rotate(math.Pi/2, vector.Y)) {
+		t.Errorf("quaternion rotation %v differs from Rotate %v", q.Rotate(v), v.Rotate(math.Pi/2, vector.Y))
+	}
+
+	m := q.Matrix()
+	if !(vec{m[0].Dot(v), m[1].Dot(v), m[2].Dot(v)}).Equal(q.Rotate(v)) {
+		t.Error("rotation matrix differs from quaternion rotation")
+	}
+
+	twice := q.Mul(q)
+	if !twice.Rotate(v).Equal(v.Rotate(math.Pi, vector.Y)) {
+		t.Error("product of quaternions is not the combined rotation")
+	}
+
+	if !q.Conjugate().Rotate(q.Rotate(v)).Equal(v) {
+		t.Error("conjugate is not the inverse rotation")
+	}
+}
+
+func TestRigidBodyIntegrate(t *testing.T) {
+	b := vector.NewRigidBody(2, vector.SphereInertia(2, 1))
+
+	b.ApplyForce(vec{4, 0, 0})
+	b.ApplyTorque(vec{0, 0, 0.8})
+	b.Integrate(1)
+
+	if !b.LinearVelocity.Equal(vec{2, 0, 0}) || !b.Position.Equal(vec{2, 0, 0}) {
+		t.Errorf("unexpected velocity %v or position %v", b.LinearVelocity, b.Position)
+	}
+
+	if !b.AngularVelocity.Equal(vec{0, 0, 1}) {
+		t.Errorf("unexpected angular velocity %v", b.AngularVelocity)
+	}
+
+	// forces are cleared after integrating
+	b.Integrate(1)
+	if !b.LinearVelocity.Equal(vec{2, 0, 0}) {
+		t.Error("force was applied more than once")
+	}
+
+	spinning := vector.NewRigidBody(1, vector.BoxInertia(1, vec{1, 1, 1}))
+	spinning.AngularVelocity = vec{0, 0, math.Pi / 2}
+	for i := 0; i < 1000; i++ {
+		spinning.Integrate(0.001)
+	}
+
+	if spinning.Orientation.Rotate(vector.X).Sub(vector.Y).Magnitude() > 1e-3 {
+		t.Errorf("expected a quarter turn around z, got %v", spinning.Orientation.Rotate(vector.X))
+	}
+}
+
+func TestContactRestitution(t *testing.T) {
+	ground := vector.NewRigidBody(0, nil)
+	ball := vector.NewRigidBody(1, vector.SphereInertia(1, 1))
+	ball.Position = vec{0, 1, 0}
+	ball.LinearVelocity = vec{0, -4, 0}
+
+	vector.Contact{
+		A: ground, B: ball,
+		Point:       vec{0, 0, 0},
+		Normal:      vec{0, 1, 0},
+		Restitution: 0.5,
+	}.Resolve()
+
+	if !ball.LinearVelocity.Equal(vec{0, 2, 0}) {
+		t.Errorf("expected the ball to bounce with half the speed, got %v", ball.LinearVelocity)
+	}
+
+	if !ground.LinearVelocity.Equal(vec{0, 0, 0}) {
+		t.Error("static body was moved by the contact")
+	}
+}
+
+func TestContactMomentum(t *testing.T) {
+	a, b := vector.NewRigidBody(1, vector.SphereInertia(1, 1)), vector.NewRigidBody(3, vector.SphereInertia(3, 1))
+	a.LinearVelocity, b.Position, b.LinearVelocity = vec{2, 1, 0}, vec{2, 0, 0}, vec{-1, 0, 0}
+
+	before := a.LinearVelocity.Add(b.LinearVelocity.Scale(3))
+
+	vector.Contact{
+		A: a, B: b,
+		Point:       vec{1, 0, 0},
+		Normal:      vec{1, 0, 0},
+		Restitution: 1,
+		Friction:    0.5,
+	}.Resolve()
+
+	after := a.LinearVelocity.Add(b.LinearVelocity.Scale(3))
+	if !after.Equal(before) {
+		t.Errorf("momentum was not conserved, before %v after %v", before, after)
+	}
+
+	if a.LinearVelocity.X() >= 0 || b.LinearVelocity.X() <= 0 {
+		t.Errorf("bodies are still moving into each other, %v and %v", a.LinearVelocity, b.LinearVelocity)
+	}
+
+	if a.AngularVelocity.Magnitude() == 0 {
+		t.Error("friction at the contact did not spin the body")
+	}
+}
@@ -0,0 +1,190 @@
+package vector
+
+import (
+	"math"
+	"sort"
+)
+
+// SparseVector is a vector where most components are zero, stored as the
+// indices of the non-zero components in ascending order and their values.
+// Dim is the dimension of the vector, which is only used when converting it
+// to a Vector.
+//
+// The indices have to be sorted and unique, which NewSparseVector makes sure
+// of. All operations keep them that way.
+type SparseVector struct {
+	Dim     int
+	Indices []int
+	Values  []float64
+}
+
+// NewSparseVector returns a sparse vector of the given dimension from a list of
+// indices and their values in any order, where the values of repeated indices
+// are summed. It returns ErrNotSameLength if the lists differ in length and
+// ErrNotValidIndex if an index is outside of the dimension.
+func NewSparseVector(dim int, indices []int, values []float64) (SparseVector, error) {
+	if len(indices) != len(values) {
+		return SparseVector{}, ErrNotSameLength
+	}
+
+	order := make([]int, len(indices))
+	for i := range order {
+		if indices[i] < 0 || indices[i] >= dim {
+			return SparseVector{}, ErrNotValidIndex
+		}
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return indices[order[i]] < indices[order[j]]
+	})
+
+	result := SparseVector{Dim: dim, Indices: make([]int, 0, len(order)), Values: make([]float64, 0, len(order))}
+	for _, i := range order {
+		if n := len(result.Indices); n > 0 && result.Indices[n-1] == indices[i] {
+			result.Values[n-1] += values[i]
+			continue
+		}
+
+		result.Indices = append(result.Indices, indices[i])
+		result.Values = append(result.Values, values[i])
+	}
+
+	return result, nil
+}
+
+// Sparse returns the sparse vector of the non-zero components of a vector
+func Sparse(a Vector) SparseVector {
+	result := SparseVector{Dim: len(a)}
+	for i, v := range a {
+		if v != 0 {
+			result.Indices = append(result.Indices, i)
+			result.Values = append(result.Values, v)
+		}
+	}
+	return result
+}
+
+// Dense returns the sparse vector as a Vector of its dimension
+func (a SparseVector) Dense() Vector {
+	result := make(Vector, a.Dim)
+	for k, i := range a.Indices {
+		if i < a.Dim {
+			result[i] = a.Values[k]
+		}
+	}
+	return result
+}
+
+// Clone a sparse vector
+func (a SparseVector) Clone() SparseVector {
+	return SparseVector{
+		Dim:     a.Dim,
+		Indices: append([]int(nil), a.Indices...),
+		Values:  clone(a.Values),
+	}
+}
+
+// Add returns the sum of two sparse vectors, with the larger of the two
+// dimensions
+func (a SparseVector) Add(b SparseVector) SparseVector {
+	result := SparseVector{
+		Dim:     a.Dim,
+		Indices: make([]int, 0, len(a.Indices)+len(b.Indices)),
+		Values:  make([]float64, 0, len(a.Values)+len(b.Values)),
+	}
+
+	if b.Dim > result.Dim {
+		result.Dim = b.Dim
+	}
+
+	i, j := 0, 0
+	for i < len(a.Indices) || j < len(b.Indices) {
+		switch {
+		case j == len(b.Indices) || i < len(a.Indices) && a.Indices[i] < b.Indices[j]:
+			result.Indices = append(result.Indices, a.Indices[i])
+			result.Values = append(result.Values, a.Values[i])
+			i++
+		case i == len(a.Indices) || b.Indices[j] < a.Indices[i]:
+			result.Indices = append(result.Indices, b.Indices[j])
+			result.Values = append(result.Values, b.Values[j])
+			j++
+		default:
+			result.Indices = append(result.Indices, a.Indices[i])
+			result.Values = append(result.Values, a.Values[i]+b.Values[j])
+			i++
+			j++
+		}
+	}
+
+	return result
+}
+
+// Scale returns the sparse vector scaled by the given size
+func (a SparseVector) Scale(size float64) SparseVector {
+	result := a.Clone()
+	scale(result.Values, size)
+	return result
+}
+
+// Magnitude of a sparse vector
+func (a SparseVector) Magnitude() float64 {
+	return magnitude(a.Values)
+}
+
+// Dot product of two sparse vectors, which only visits the indices of both
+func (a SparseVector) Dot(b SparseVector) float64 {
+	var result float64
+	i, j := 0, 0
+	for i < len(a.Indices) && j < len(b.Indices) {
+		switch {
+		case a.Indices[i] < b.Indices[j]:
+			i++
+		case a.Indices[i] > b.Indices[j]:
+			j++
+		default:
+			result += a.Values[i] * b.Values[j]
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// DotVector returns the dot product of the sparse vector and a vector, where
+// the components missing from the vector count as zero
+func (a SparseVector) DotVector(b Vector) float64 {
+	var result float64
+	for k, i := range a.Indices {
+		if i >= len(b) {
+			break
+		}
+		result += a.Values[k] * b[i]
+	}
+	return result
+}
+
+// Cosine returns one minus the cosine similarity of two sparse vectors, like
+// the Cosine metric, which is 0 for vectors pointing in the same direction and
+// 2 for opposite vectors
+func (a SparseVector) Cosine(b SparseVector) float64 {
+	l := a.Magnitude() * b.Magnitude()
+	if l == 0 {
+		return 1
+	}
+
+	return 1 - math.Max(-1, math.Min(1, a.Dot(b)/l))
+}
+
+// AddSparse adds a sparse vector in place in the mutable vector, by scattering
+// its values into their indices. Indices outside of the mutable vector are
+// ignored.
+func (a MutableVector) AddSparse(b SparseVector) MutableVector {
+	for k, i := range b.Indices {
+		if i >= len(a) {
+			break
+		}
+		a[i] += b.Values[k]
+	}
+	return a
+}
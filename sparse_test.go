@@ -0,0 +1,109 @@
+package vector_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func randomSparse(r *rand.Rand, dim, n int) vector.SparseVector {
+	indices, values := make([]int, n), make([]float64, n)
+	for i := range indices {
+		indices[i], values[i] = r.Intn(dim), r.NormFloat64()
+	}
+
+	s, _ := vector.NewSparseVector(dim, indices, values)
+	return s
+}
+
+func TestNewSparseVector(t *testing.T) {
+	s, err := vector.NewSparseVector(6, []int{4, 1, 4, 0}, []float64{2, 3, 1, -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !s.Dense().Equal(vec{-1, 3, 0, 0, 3, 0}) {
+		t.Errorf("unexpected sparse vector %v", s.Dense())
+	}
+
+	if len(s.Indices) != 3 || s.Indices[0] != 0 || s.Indices[1] != 1 || s.Indices[2] != 4 {
+		t.Errorf("expected sorted unique indices, got %v", s.Indices)
+	}
+
+	if _, err := vector.NewSparseVector(3, []int{3}, []float64{1}); err != vector.ErrNotValidIndex {
+		t.Errorf("expected ErrNotValidIndex, got %v", err)
+	}
+
+	if _, err := vector.NewSparseVector(3, []int{1}, []float64{1, 2}); err != vector.ErrNotSameLength {
+		t.Errorf("expected ErrNotSameLength, got %v", err)
+	}
+
+	dense := vec{0, 2, 0, 0, -1}
+	if sparse := vector.Sparse(dense); len(sparse.Indices) != 2 || !sparse.Dense().Equal(dense) {
+		t.Errorf("expected %v to round trip, got %v", dense, sparse)
+	}
+}
+
+func TestSparseVectorArithmetic(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		a, b := randomSparse(r, 50, 10), randomSparse(r, 60, 20)
+		da, db := a.Dense(), b.Dense()
+
+		if sum := a.Add(b).Dense(); sum.Sub(append(da, make(vec, 10)...).Add(db)).Magnitude() > 1e-12 {
+			t.Fatalf("expected %v + %v, got %v", da, db, sum)
+		}
+
+		if d := a.Dot(b); math.Abs(d-da.Dot(db[:50])) > 1e-12 {
+			t.Fatalf("expected dot product %v, got %v", da.Dot(db[:50]), d)
+		}
+
+		if d := a.DotVector(db); math.Abs(d-da.Dot(db[:50])) > 1e-12 {
+			t.Fatalf("expected dot product with vector %v, got %v", da.Dot(db[:50]), d)
+		}
+
+		if math.Abs(a.Magnitude()-da.Magnitude()) > 1e-12 {
+			t.Fatalf("expected magnitude %v, got %v", da.Magnitude(), a.Magnitude())
+		}
+
+		if c := a.Cosine(b); math.Abs(c-vector.Cosine(da, db)) > 1e-12 {
+			t.Fatalf("expected cosine %v, got %v", vector.Cosine(da, db), c)
+		}
+
+		if s := a.Scale(-2).Dense(); !s.Equal(da.Scale(-2)) {
+			t.Fatalf("expected %v, got %v", da.Scale(-2), s)
+		}
+	}
+}
+
+func TestSparseVectorScale(t *testing.T) {
+	a, _ := vector.NewSparseVector(3, []int{1}, []float64{2})
+	a.Scale(3)
+
+	if a.Values[0] != 2 {
+		t.Error("expected Scale to leave the sparse vector untouched")
+	}
+}
+
+func TestMutableVectorAddSparse(t *testing.T) {
+	s, _ := vector.NewSparseVector(10, []int{0, 2, 9}, []float64{1, 2, 3})
+	v := vector.MutableVector{1, 1, 1, 1}
+
+	if !v.AddSparse(s).Equal(vec{2, 1, 3, 1}) {
+		t.Errorf("expected the values scattered into the vector, got %v", v)
+	}
+}
+
+func BenchmarkSparseVectorDotVector(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	s := randomSparse(r, 100000, 100)
+	dense := make(vec, 100000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.DotVector(dense)
+	}
+}
@@ -0,0 +1,465 @@
+package vector
+
+import (
+	"math"
+	"sort"
+)
+
+// Accumulator computes descriptive statistics over a stream of vectors
+// without holding them in memory, using Welford's online algorithm. Vectors
+// pushed to the accumulator are treated as having the dimension of the
+// accumulator, missing components are read as 0 and extra components are
+// ignored.
+type Accumulator struct {
+	n      int
+	weight float64
+	mean   Vector
+	m2     Vector
+	min    Vector
+	max    Vector
+	cov    []Vector
+	delta  Vector
+}
+
+// NewAccumulator returns an accumulator for vectors of the given dimension
+// that keeps track of the mean, variance, min and max.
+func NewAccumulator(dim int) *Accumulator {
+	return &Accumulator{
+		mean:  make(Vector, dim),
+		m2:    make(Vector, dim),
+		min:   make(Vector, dim),
+		max:   make(Vector, dim),
+		delta: make(Vector, dim),
+	}
+}
+
+// NewCovarianceAccumulator returns an accumulator that in addition to what
+// NewAccumulator keeps track of, also keeps the full covariance matrix. This
+// makes every push O(dim²) instead of O(dim).
+func NewCovarianceAccumulator(dim int) *Accumulator {
+	a := NewAccumulator(dim)
+	a.cov = zeros(dim, dim)
+	return a
+}
+
+// Push adds a vector to the accumulator
+func (a *Accumulator) Push(v Vector) {
+	a.PushWeighted(v, 1)
+}
+
+// PushWeighted adds a vector to the accumulator with the given weight. The
+// weights are treated as frequency weights, so pushing a vector with the
+// weight 2 is the same as pushing it twice.
+func (a *Accumulator) PushWeighted(v Vector, weight float64) {
+	if weight <= 0 {
+		return
+	}
+
+	a.n++
+	a.weight += weight
+	r := weight / a.weight
+
+	for i := range a.mean {
+		var value float64
+		if i < len(v) {
+			value = v[i]
+		}
+
+		if a.n == 1 || value < a.min[i] {
+			a.min[i] = value
+		}
+
+		if a.n == 1 || value > a.max[i] {
+			a.max[i] = value
+		}
+
+		a.delta[i] = value - a.mean[i]
+		a.mean[i] += r * a.delta[i]
+		a.m2[i] += weight * a.delta[i] * (value - a.mean[i])
+	}
+
+	if a.cov == nil {
+		return
+	}
+
+	// the delta to the new mean is (1 - r) times the delta to the old mean
+	for i := range a.cov {
+		for j := i; j < len(a.cov); j++ {
+			a.cov[i][j] += weight * (1 - r) * a.delta[i] * a.delta[j]
+		}
+	}
+}
+
+// Count returns the number of vectors pushed to the accumulator
+func (a *Accumulator) Count() int {
+	return a.n
+}
+
+// Mean returns the mean of the pushed vectors
+func (a *Accumulator) Mean() Vector {
+	return clone(a.mean)
+}
+
+// Variance returns the componentwise sample variance of the pushed vectors
+func (a *Accumulator) Variance() Vector {
+	if a.weight <= 1 {
+		return make(Vector, len(a.m2))
+	}
+
+	return scale(clone(a.m2), 1/(a.weight-1))
+}
+
+// StdDev returns the componentwise sample standard deviation of the pushed
+// vectors
+func (a *Accumulator) StdDev() Vector {
+	v := a.Variance()
+	for i := range v {
+		v[i] = math.Sqrt(v[i])
+	}
+	return v
+}
+
+// Covariance returns the sample covariance matrix of the pushed vectors as a
+// list of rows. It returns nil if the accumulator was not created with
+// NewCovarianceAccumulator.
+func (a *Accumulator) Covariance() []Vector {
+	if a.cov == nil {
+		return nil
+	}
+
+	dim := len(a.cov)
+	c := zeros(dim, dim)
+
+	if a.weight <= 1 {
+		return c
+	}
+
+	for i := 0; i < dim; i++ {
+		for j := i; j < dim; j++ {
+			c[i][j] = a.cov[i][j] / (a.weight - 1)
+			c[j][i] = c[i][j]
+		}
+	}
+
+	return c
+}
+
+// Min returns the componentwise minimum of the pushed vectors
+func (a *Accumulator) Min() Vector {
+	return clone(a.min)
+}
+
+// Max returns the componentwise maximum of the pushed vectors
+func (a *Accumulator) Max() Vector {
+	return clone(a.max)
+}
+
+// PercentileAccumulator estimates a componentwise percentile over a stream of
+// vectors in constant memory with the P² algorithm by Jain and Chlamtac. Up to
+// five pushed vectors the result is exact.
+type PercentileAccumulator struct {
+	p       float64
+	n       int
+	heights []Vector
+	pos     [][5]float64
+	desired [5]float64
+	inc     [5]float64
+}
+
+// NewPercentileAccumulator returns an accumulator that estimates the p-th
+// percentile, with p between 0 and 100, of vectors with the given dimension.
+func NewPercentileAccumulator(dim int, p float64) *PercentileAccumulator {
+	q := math.Max(0, math.Min(100, p)) / 100
+
+	a := &PercentileAccumulator{
+		p:       q,
+		heights: zeros(dim, 5),
+		pos:     make([][5]float64, dim),
+		desired: [5]float64{1, 1 + 2*q, 1 + 4*q, 3 + 2*q, 5},
+		inc:     [5]float64{0, q / 2, q, (1 + q) / 2, 1},
+	}
+
+	for i := range a.pos {
+		a.pos[i] = [5]float64{1, 2, 3, 4, 5}
+	}
+
+	return a
+}
+
+// Push adds a vector to the accumulator
+func (a *PercentileAccumulator) Push(v Vector) {
+	a.n++
+
+	if a.n <= 5 {
+		for i, h := range a.heights {
+			if i < len(v) {
+				h[a.n-1] = v[i]
+			}
+
+			if a.n == 5 {
+				sort.Float64s(h)
+			}
+		}
+		return
+	}
+
+	for i := range a.desired {
+		a.desired[i] += a.inc[i]
+	}
+
+	for i, h := range a.heights {
+		var value float64
+		if i < len(v) {
+			value = v[i]
+		}
+		a.push(h, &a.pos[i], value)
+	}
+}
+
+// push updates the markers of a single component with a new value
+func (a *PercentileAccumulator) push(h Vector, pos *[5]float64, value float64) {
+	var k int
+	switch {
+	case value < h[0]:
+		h[0], k = value, 0
+	case value >= h[4]:
+		h[4], k = value, 3
+	default:
+		for k = 0; k < 3 && value >= h[k+1]; k++ {
+		}
+	}
+
+	for i := k + 1; i < 5; i++ {
+		pos[i]++
+	}
+
+	for i := 1; i < 4; i++ {
+		d := a.desired[i] - pos[i]
+
+		if (d >= 1 && pos[i+1]-pos[i] > 1) || (d <= -1 && pos[i-1]-pos[i] < -1) {
+			s := math.Copysign(1, d)
+			hp := h[i] + s/(pos[i+1]-pos[i-1])*
+				((pos[i]-pos[i-1]+s)*(h[i+1]-h[i])/(pos[i+1]-pos[i])+
+					(pos[i+1]-pos[i]-s)*(h[i]-h[i-1])/(pos[i]-pos[i-1]))
+
+			if h[i-1] < hp && hp < h[i+1] {
+				h[i] = hp
+			} else {
+				j := i + int(s)
+				h[i] += s * (h[j] - h[i]) / (pos[j] - pos[i])
+			}
+
+			pos[i] += s
+		}
+	}
+}
+
+// Percentile returns the estimated percentile of the pushed vectors
+func (a *PercentileAccumulator) Percentile() Vector {
+	result := make(Vector, len(a.heights))
+
+	if a.n == 0 {
+		return result
+	}
+
+	if a.n <= 5 {
+		for i, h := range a.heights {
+			result[i] = percentile(clone(h[:a.n]), a.p)
+		}
+		return result
+	}
+
+	for i, h := range a.heights {
+		result[i] = h[2]
+	}
+
+	return result
+}
+
+// Mean returns the mean of a set of vectors
+func Mean(vectors []Vector) Vector {
+	a := accumulate(vectors, false)
+	if a == nil {
+		return nil
+	}
+	return a.Mean()
+}
+
+// WeightedMean returns the weighted mean of a set of vectors, where the weight
+// at index i belongs to the vector at index i.
+func WeightedMean(vectors []Vector, weights Vector) (Vector, error) {
+	if len(vectors) != len(weights) {
+		return nil, ErrNotSameLength
+	}
+
+	if len(vectors) == 0 {
+		return nil, nil
+	}
+
+	a := NewAccumulator(len(vectors[0]))
+	for i := range vectors {
+		a.PushWeighted(vectors[i], weights[i])
+	}
+
+	return a.Mean(), nil
+}
+
+// Variance returns the componentwise sample variance of a set of vectors
+func Variance(vectors []Vector) Vector {
+	a := accumulate(vectors, false)
+	if a == nil {
+		return nil
+	}
+	return a.Variance()
+}
+
+// StdDev returns the componentwise sample standard deviation of a set of
+// vectors
+func StdDev(vectors []Vector) Vector {
+	a := accumulate(vectors, false)
+	if a == nil {
+		return nil
+	}
+	return a.StdDev()
+}
+
+// Covariance returns the sample covariance matrix of a set of vectors as a
+// list of rows
+func Covariance(vectors []Vector) []Vector {
+	a := accumulate(vectors, true)
+	if a == nil {
+		return nil
+	}
+	return a.Covariance()
+}
+
+// Min returns the componentwise minimum of a set of vectors
+func Min(vectors []Vector) Vector {
+	a := accumulate(vectors, false)
+	if a == nil {
+		return nil
+	}
+	return a.Min()
+}
+
+// Max returns the componentwise maximum of a set of vectors
+func Max(vectors []Vector) Vector {
+	a := accumulate(vectors, false)
+	if a == nil {
+		return nil
+	}
+	return a.Max()
+}
+
+// Median returns the componentwise median of a set of vectors
+func Median(vectors []Vector) Vector {
+	return Percentile(vectors, 50)
+}
+
+// Percentile returns the componentwise p-th percentile, with p between 0 and
+// 100, of a set of vectors. Values between two samples are linearly
+// interpolated.
+func Percentile(vectors []Vector, p float64) Vector {
+	if len(vectors) == 0 {
+		return nil
+	}
+
+	q := math.Max(0, math.Min(100, p)) / 100
+	result, values := make(Vector, len(vectors[0])), make([]float64, len(vectors))
+
+	for i := range result {
+		for j, v := range vectors {
+			values[j] = 0
+			if i < len(v) {
+				values[j] = v[i]
+			}
+		}
+		result[i] = percentile(values, q)
+	}
+
+	return result
+}
+
+// GeometricMedian returns the point minimizing the sum of distances to a set of
+// vectors, found with Weiszfeld's algorithm.
+func GeometricMedian(vectors []Vector) Vector {
+	if len(vectors) == 0 {
+		return nil
+	}
+
+	median := Mean(vectors)
+	next, d := make(Vector, len(median)), make(Vector, len(median))
+
+	for iteration := 0; iteration < 1000; iteration++ {
+		var weights float64
+
+		for i := range next {
+			next[i] = 0
+		}
+
+		for _, v := range vectors {
+			for i := range d {
+				d[i] = 0
+				if i < len(v) {
+					d[i] = v[i]
+				}
+			}
+
+			l := magnitude(sub(clone(d), median))
+
+			// the median coincides with one of the vectors, the weight of it
+			// would be infinite so it is left out of the step
+			if l < 1e-12 {
+				continue
+			}
+
+			weights += 1 / l
+			add(next, scale(d, 1/l))
+		}
+
+		if weights == 0 {
+			return median
+		}
+
+		scale(next, 1/weights)
+		step := magnitude(sub(clone(next), median))
+		copy(median, next)
+
+		if step < 1e-10 {
+			break
+		}
+	}
+
+	return median
+}
+
+func accumulate(vectors []Vector, covariance bool) *Accumulator {
+	if len(vectors) == 0 {
+		return nil
+	}
+
+	var a *Accumulator
+	if covariance {
+		a = NewCovarianceAccumulator(len(vectors[0]))
+	} else {
+		a = NewAccumulator(len(vectors[0]))
+	}
+
+	for _, v := range vectors {
+		a.Push(v)
+	}
+
+	return a
+}
+
+// percentile sorts the values in place and returns the q-th quantile with q
+// between 0 and 1
+func percentile(values []float64, q float64) float64 {
+	sort.Float64s(values)
+
+	rank := q * float64(len(values)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+
+	return values[lo] + (rank-float64(lo))*(values[hi]-values[lo])
+}
@@ -0,0 +1,131 @@
+package vector_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/quartercastle/vector"
+)
+
+func TestStatistics(t *testing.T) {
+	data := []vec{{1, 10}, {2, 20}, {3, 30}, {4, 40}}
+
+	if !vector.Mean(data).Equal(vec{2.5, 25}) {
+		t.Errorf("unexpected mean %v", vector.Mean(data))
+	}
+
+	if !vector.Variance(data).Equal(vec{5. / 3, 500. / 3}) {
+		t.Errorf("unexpected variance %v", vector.Variance(data))
+	}
+
+	if !vector.StdDev(data).Equal(vec{math.Sqrt(5. / 3), math.Sqrt(500. / 3)}) {
+		t.Errorf("unexpected standard deviation %v", vector.StdDev(data))
+	}
+
+	if !vector.Min(data).Equal(vec{1, 10}) || !vector.Max(data).Equal(vec{4, 40}) {
+		t.Errorf("unexpected min %v or max %v", vector.Min(data), vector.Max(data))
+	}
+
+	if !vector.Median(data).Equal(vec{2.5, 25}) {
+		t.Errorf("unexpected median %v", vector.Median(data))
+	}
+
+	if !vector.Percentile(data, 100).Equal(vec{4, 40}) {
+		t.Errorf("unexpected percentile %v", vector.Percentile(data, 100))
+	}
+
+	cov := vector.Covariance(data)
+	if !cov[0].Equal(vec{5. / 3, 50. / 3}) || !cov[1].Equal(vec{50. / 3, 500. / 3}) {
+		t.Errorf("unexpected covariance %v", cov)
+	}
+
+	mean, err := vector.WeightedMean(data, vec{1, 0, 0, 1})
+	if err != nil || !mean.Equal(vec{2.5, 25}) {
+		t.Errorf("unexpected weighted mean %v", mean)
+	}
+
+	if _, err := vector.WeightedMean(data, vec{1}); err != vector.ErrNotSameLength {
+		t.Error("did not return ErrNotSameLength for weights of the wrong length")
+	}
+}
+
+func TestAccumulatorMatchesBatch(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	data := make([]vec, 500)
+	for i := range data {
+		data[i] = vec{r.NormFloat64(), r.Float64() * 10, r.ExpFloat64()}
+	}
+
+	acc := vector.NewCovarianceAccumulator(3)
+	for _, v := range data {
+		acc.Push(v)
+	}
+
+	if acc.Count() != len(data) {
+		t.Errorf("expected count %v, got %v", len(data), acc.Count())
+	}
+
+	if !acc.Mean().Equal(vector.Mean(data)) {
+		t.Error("accumulated mean differs from batch mean")
+	}
+
+	cov := acc.Covariance()
+	for i := range cov {
+		for j := range cov[i] {
+			var expected float64
+			mean := vector.Mean(data)
+			for _, v := range data {
+				expected += (v[i] - mean[i]) * (v[j] - mean[j])
+			}
+			expected /= float64(len(data) - 1)
+
+			if math.Abs(cov[i][j]-expected) > 1e-8 {
+				t.Errorf("covariance [%v][%v] expected %v, got %v", i, j, expected, cov[i][j])
+			}
+		}
+	}
+}
+
+func TestPercentileAccumulator(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	data := make([]vec, 10000)
+	acc := vector.NewPercentileAccumulator(2, 90)
+
+	for i := range data {
+		data[i] = vec{r.Float64(), r.NormFloat64()}
+		acc.Push(data[i])
+	}
+
+	estimate, exact := acc.Percentile(), vector.Percentile(data, 90)
+
+	for i := range exact {
+		if math.Abs(estimate[i]-exact[i]) > 0.05 {
+			t.Errorf("estimated percentile %v too far from exact %v", estimate, exact)
+		}
+	}
+
+	small, pushed := vector.NewPercentileAccumulator(1, 90), []vec{}
+	for i := 1; i <= 5; i++ {
+		pushed = append(pushed, vec{float64(i)})
+		small.Push(pushed[i-1])
+
+		if expected := vector.Percentile(pushed, 90); !small.Percentile().Equal(expected) {
+			t.Errorf("expected the exact percentile %v after %v vectors, got %v", expected, i, small.Percentile())
+		}
+	}
+}
+
+func TestGeometricMedian(t *testing.T) {
+	data := []vec{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {100, 100}}
+
+	median := vector.GeometricMedian(data)
+
+	if median.Sub(vec{0.5, 0.5}).Magnitude() > 0.5 {
+		t.Errorf("geometric median %v should be robust against the outlier", median)
+	}
+
+	if !vector.GeometricMedian([]vec{{1, 1}, {3, 3}, {2, 2}}).Equal(vec{2, 2}) {
+		t.Error("geometric median of collinear points should be the middle point")
+	}
+}
@@ -17,7 +17,7 @@ func axpyUnitaryTo(dst []float64, alpha float64, x, y []float64) {
 // This function is from the gonum repository:
 // https://github.com/gonum/gonum/blob/c3867503e73e5c3fee7ab93e3c2c562eb2be8178/internal/asm/f64/scal.go#L23
 func scalUnitaryTo(dst []float64, alpha float64, x []float64) {
-	for i := range x {
-		dst[i] *= alpha
+	for i, v := range x {
+		dst[i] = alpha * v
 	}
 }
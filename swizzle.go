@@ -0,0 +1,141 @@
+package vector
+
+// Swizzler is a swizzle mask compiled into the indices of the components it
+// names, so a mask used in a hot loop is only parsed once
+type Swizzler []int
+
+// swizzleSets are the letters that can name the components in a mask, either
+// as coordinates or as colors
+var swizzleSets = [...]string{"xyzw", "rgba"}
+
+// CompileSwizzle parses a mask of component names into a Swizzler. A mask uses
+// either the coordinate names xyzw or the color names rgba, like "zyx" or
+// "bgra". It returns ErrNotValidSwizzleIndex for an empty mask, a mask with
+// other characters or a mask mixing the two sets of names.
+func CompileSwizzle(mask string) (Swizzler, error) {
+	if len(mask) == 0 {
+		return nil, ErrNotValidSwizzleIndex
+	}
+
+	for _, set := range swizzleSets {
+		s := make(Swizzler, len(mask))
+		valid := true
+
+		for i := 0; i < len(mask) && valid; i++ {
+			s[i] = -1
+			for j := 0; j < len(set); j++ {
+				if mask[i] == set[j] {
+					s[i] = j
+				}
+			}
+			valid = s[i] >= 0
+		}
+
+		if valid {
+			return s, nil
+		}
+	}
+
+	return nil, ErrNotValidSwizzleIndex
+}
+
+// Swizzle returns a new vector of the components named by the swizzler. It
+// returns ErrNotValidSwizzleIndex if a component lies outside of the vector.
+func (s Swizzler) Swizzle(a Vector) (Vector, error) {
+	return swizzle(a, s...)
+}
+
+// SwizzleTo writes the components named by the swizzler into dst, which must
+// be at least as long as the swizzler and must not share memory with a, so it
+// can be reused without allocating. It returns ErrNotValidSwizzleIndex if a
+// component lies outside of the vector and ErrNotSameDimensions if dst is too
+// short.
+func (s Swizzler) SwizzleTo(dst MutableVector, a Vector) error {
+	if len(dst) < len(s) {
+		return ErrNotSameDimensions
+	}
+
+	for _, i := range s {
+		if i < 0 || i >= len(a) {
+			return ErrNotValidSwizzleIndex
+		}
+	}
+
+	for k, i := range s {
+		dst[k] = a[i]
+	}
+
+	return nil
+}
+
+// Set writes the components of b, in order, into the components of dst named
+// by the swizzler, so the mask "xz" sets the x and z components from a
+// 2-dimensional vector. A mask naming a component twice can not be written to.
+// It returns ErrNotValidSwizzleIndex if a component is named twice or lies
+// outside of dst, and ErrNotSameDimensions if b is shorter than the swizzler.
+func (s Swizzler) Set(dst MutableVector, b Vector) error {
+	if len(b) < len(s) {
+		return ErrNotSameDimensions
+	}
+
+	// masks are short, so comparing every pair finds repeated components
+	// without allocating
+	for k, i := range s {
+		if i < 0 || i >= len(dst) {
+			return ErrNotValidSwizzleIndex
+		}
+
+		for _, j := range s[:k] {
+			if i == j {
+				return ErrNotValidSwizzleIndex
+			}
+		}
+	}
+
+	for k, i := range s {
+		dst[i] = b[k]
+	}
+
+	return nil
+}
+
+// SwizzleStr returns a clone of the vector altered using a mask of component
+// names, like "zyx" or "bgra", see CompileSwizzle. It returns
+// ErrNotValidSwizzleIndex if the mask is not valid or names a component
+// outside of the vector.
+func (a Vector) SwizzleStr(mask string) (Vector, error) {
+	s, err := CompileSwizzle(mask)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Swizzle(a)
+}
+
+// SwizzleStr returns a new mutable vector of the components named by a mask,
+// like "zyx" or "bgra", see CompileSwizzle. The dimension of the result is the
+// length of the mask, so it can not be done in place. It returns
+// ErrNotValidSwizzleIndex if the mask is not valid or names a component
+// outside of the vector.
+func (a MutableVector) SwizzleStr(mask string) (MutableVector, error) {
+	s, err := CompileSwizzle(mask)
+	if err != nil {
+		return nil, err
+	}
+
+	return swizzle(a, s...)
+}
+
+// SetSwizzle writes the components of b in place into the components named by
+// a mask, so a.SetSwizzle("xz", vec{1, 2}) sets x to 1 and z to 2. It returns
+// ErrNotValidSwizzleIndex if the mask is not valid, names a component twice or
+// names a component outside of the vector, and ErrNotSameDimensions if b is
+// shorter than the mask.
+func (a MutableVector) SetSwizzle(mask string, b Vector) error {
+	s, err := CompileSwizzle(mask)
+	if err != nil {
+		return err
+	}
+
+	return s.Set(a, b)
+}
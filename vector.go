@@ -1,7 +1,13 @@
 package vector
 
+import "math"
+
 // Vector is the definition of a row vector that contains scalars as
 // 64 bit floats
+//
+// Vectors of different dimensions can be combined, where the first vector
+// decides the dimension of the result and components missing from the other
+// count as zero, or for elementwise operations like Mul leave it unchanged.
 type Vector []float64
 
 // Clone a vector
@@ -126,3 +132,176 @@ func (a Vector) Z() float64 {
 
 	return a[z]
 }
+
+// W is corresponding to doing a Vector[3] lookup, if index 3 does not exist yet, a
+// 0 will be returned instead
+func (a Vector) W() float64 {
+	if len(a) < 4 {
+		return 0
+	}
+
+	return a[w]
+}
+
+// Get is corresponding to doing a Vector[i] lookup, if index i does not exist yet, a
+// 0 will be returned instead
+func (a Vector) Get(i int) float64 {
+	return component(a, i)
+}
+
+// Resize returns a clone of the vector with n dimensions, where extra
+// dimensions are cut and missing dimensions are filled with zeros, and a
+// negative n gives an empty vector
+func (a Vector) Resize(n int) Vector {
+	if n < 0 {
+		n = 0
+	}
+	return resize(a, n)
+}
+
+// ToPolar converts the x and y components of a cartesian vector to polar
+// coordinates, returned as Vector{r, θ} where θ is the angle from the x axis in
+// the range [-π, π].
+func (a Vector) ToPolar() Vector {
+	return toPolar(a)
+}
+
+// FromPolar converts a vector of polar coordinates Vector{r, θ} to cartesian
+// coordinates, it is the inverse of ToPolar.
+func (a Vector) FromPolar() Vector {
+	return fromPolar(a)
+}
+
+// ToCylindrical converts a cartesian vector to cylindrical coordinates,
+// returned as Vector{ρ, φ, z} where ρ and φ are the polar coordinates of the x
+// and y components.
+func (a Vector) ToCylindrical() Vector {
+	return toCylindrical(a)
+}
+
+// FromCylindrical converts a vector of cylindrical coordinates Vector{ρ, φ, z}
+// to cartesian coordinates, it is the inverse of ToCylindrical.
+func (a Vector) FromCylindrical() Vector {
+	return fromCylindrical(a)
+}
+
+// ToSpherical converts a cartesian vector to spherical coordinates using the
+// physics (ISO 80000-2) convention, returned as Vector{r, θ, φ} where θ is the
+// polar angle from the z axis in the range [0, π] and φ is the azimuthal angle
+// from the x axis in the range [-π, π].
+//
+// The math convention swaps the two angles, so Vector{r, θ, φ} has θ as the
+// azimuthal angle and φ as the polar angle. It can be had by swizzling the
+// result with Swizzle(0, 2, 1), which also works the other way around before
+// calling FromSpherical.
+func (a Vector) ToSpherical() Vector {
+	return toSpherical(a)
+}
+
+// FromSpherical converts a vector of spherical coordinates Vector{r, θ, φ}, in
+// the physics convention described on ToSpherical, to cartesian coordinates.
+func (a Vector) FromSpherical() Vector {
+	return fromSpherical(a)
+}
+
+// ToHyperspherical converts an n-dimensional cartesian vector to hyperspherical
+// coordinates Vector{r, φ1, ..., φn-1}, where
+//
+//	x1 = r cos(φ1)
+//	x2 = r sin(φ1) cos(φ2)
+//	...
+//	xn-1 = r sin(φ1) ... sin(φn-2) cos(φn-1)
+//	xn = r sin(φ1) ... sin(φn-2) sin(φn-1)
+//
+// All angles are in the range [0, π] except the last which is in [-π, π]. A
+// 1-dimensional vector has no angles and is returned unchanged. For
+// 2-dimensional vectors this is the same as ToPolar, note that for
+// 3-dimensional vectors φ1 is measured from the x axis and not the z axis as in
+// ToSpherical.
+func (a Vector) ToHyperspherical() Vector {
+	return toHyperspherical(a)
+}
+
+// FromHyperspherical converts a vector of hyperspherical coordinates to
+// cartesian coordinates, it is the inverse of ToHyperspherical.
+func (a Vector) FromHyperspherical() Vector {
+	return fromHyperspherical(a)
+}
+
+// Mul multiplies the vector componentwise with another vector, which is the
+// Hadamard product. Components missing from b are left unchanged.
+func (a Vector) Mul(b Vector) Vector {
+	return mul(clone(a), b)
+}
+
+// Div divides the vector componentwise by another vector. Components missing
+// from b are left unchanged.
+func (a Vector) Div(b Vector) Vector {
+	return div(clone(a), b)
+}
+
+// Min returns the componentwise minimum of two vectors. Components missing
+// from b are left unchanged.
+func (a Vector) Min(b Vector) Vector {
+	return minimum(clone(a), b)
+}
+
+// Max returns the componentwise maximum of two vectors. Components missing
+// from b are left unchanged.
+func (a Vector) Max(b Vector) Vector {
+	return maximum(clone(a), b)
+}
+
+// Clamp limits every component to the range between the components of min and
+// max. Components missing from min or max are not limited by it.
+func (a Vector) Clamp(min, max Vector) Vector {
+	return clamp(clone(a), min, max)
+}
+
+// Abs returns the absolute value of every component
+func (a Vector) Abs() Vector {
+	return apply(clone(a), math.Abs)
+}
+
+// Floor rounds every component down to the closest integer
+func (a Vector) Floor() Vector {
+	return apply(clone(a), math.Floor)
+}
+
+// Ceil rounds every component up to the closest integer
+func (a Vector) Ceil() Vector {
+	return apply(clone(a), math.Ceil)
+}
+
+// Round rounds every component to the closest integer, with halves rounded
+// away from zero
+func (a Vector) Round() Vector {
+	return apply(clone(a), math.Round)
+}
+
+// Sqrt returns the square root of every component
+func (a Vector) Sqrt() Vector {
+	return apply(clone(a), math.Sqrt)
+}
+
+// Pow raises every component to the given power
+func (a Vector) Pow(p float64) Vector {
+	return pow(clone(a), p)
+}
+
+// Map calls f on every component and returns a vector of the results
+func (a Vector) Map(f func(float64) float64) Vector {
+	return apply(clone(a), f)
+}
+
+// Apply calls f on every component and the matching component of b, and returns a vector of the results.
+// Components missing from b are left unchanged.
+func (a Vector) Apply(b Vector, f func(a, b float64) float64) Vector {
+	return zip(clone(a), b, f)
+}
+
+// Reduce folds the components into a single value, by calling f with the
+// result so far, starting at initial, and every component in order
+func (a Vector) Reduce(f func(accumulator, v float64) float64, initial float64) float64 {
+	return reduce(a, f, initial)
+}
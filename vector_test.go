@@ -75,6 +75,13 @@ func TestRotationOfVector(t *testing.T) {
 	if len(result) > 3 {
 		t.Error("did not cut extra dimensions")
 	}
+
+	// a third of a turn around the diagonal cycles the components
+	result = vec{1, 2, 3}.Rotate(2*math.Pi/3, vec{1, 1, 1})
+
+	if !result.Equal(vec{3, 1, 2}) {
+		t.Errorf("did not rotate around an arbitrary axis, got %v", result)
+	}
 }
 
 func TestXYZGetters(t *testing.T) {
@@ -89,6 +96,78 @@ func TestXYZGetters(t *testing.T) {
 	if v2.X() != 1 || v2.Y() != 2 || v2.Z() != 3 {
 		t.Error("getter methods for x, y, z did not return 0 when expected")
 	}
+
+	v3 := vec{1, 2, 3, 4}
+
+	if v2.W() != 0 || v3.W() != 4 || vector.MutableVector(v2).W() != 0 || vector.MutableVector(v3).W() != 4 {
+		t.Error("getter method for w did not return the expected value")
+	}
+
+	if v3.Get(2) != 3 || v3.Get(7) != 0 || vector.MutableVector(v3).Get(7) != 0 || v3.Get(-1) != 0 || vector.MutableVector(v3).Get(-1) != 0 {
+		t.Error("get did not return the expected value")
+	}
+}
+
+func TestSetters(t *testing.T) {
+	v := vector.MutableVector{}
+	v = v.SetY(2).SetW(4)
+
+	if !vec(v).Equal(vec{0, 2, 0, 4}) {
+		t.Errorf("expected the vector to grow to {0, 2, 0, 4}, got %v", v)
+	}
+
+	v = v.SetX(1).SetZ(3)
+	if !vec(v).Equal(vec{1, 2, 3, 4}) {
+		t.Errorf("expected {1, 2, 3, 4}, got %v", v)
+	}
+
+	// setting an existing component happens in place
+	alias := v
+	v.Set(1, 20)
+	if alias[1] != 20 {
+		t.Error("expected setting an existing component to be done in place")
+	}
+
+	v, err := v.Set(6, 7)
+	if err != nil || !vec(v).Equal(vec{1, 20, 3, 4, 0, 0, 7}) {
+		t.Errorf("expected the vector to grow to 7 dimensions, got %v (%v)", v, err)
+	}
+
+	if result, err := v.Set(-1, 7); err != vector.ErrNotValidIndex || !vec(result).Equal(vec(v)) {
+		t.Errorf("expected a negative index to be rejected, got %v (%v)", result, err)
+	}
+}
+
+func TestResize(t *testing.T) {
+	v := vector.MutableVector{1, 2, 3, 4}
+
+	if short := v.Resize(2); !vec(short).Equal(vec{1, 2}) {
+		t.Errorf("expected {1, 2}, got %v", short)
+	}
+
+	// growing within the capacity of the vector must not bring back the cut
+	// components
+	if grown := v.Resize(2).Resize(3); !vec(grown).Equal(vec{1, 2, 0}) {
+		t.Errorf("expected {1, 2, 0}, got %v", grown)
+	}
+
+	if grown := (vector.MutableVector{1, 2}).Resize(4); !vec(grown).Equal(vec{1, 2, 0, 0}) {
+		t.Errorf("expected {1, 2, 0, 0}, got %v", grown)
+	}
+
+	a := vec{1, 2, 3}
+	if resized := a.Resize(5); !resized.Equal(vec{1, 2, 3, 0, 0}) || !a.Resize(1).Equal(vec{1}) {
+		t.Errorf("unexpected resized vector %v", resized)
+	}
+
+	a.Resize(1)[0] = 10
+	if a[0] != 1 {
+		t.Error("expected Resize to return a clone of a Vector")
+	}
+
+	if len(a.Resize(-1)) != 0 || len(v.Resize(-1)) != 0 {
+		t.Error("expected a negative size to give an empty vector")
+	}
 }
 
 func TestSwizzling(t *testing.T) {
@@ -100,3 +179,174 @@ func TestSwizzling(t *testing.T) {
 	}
 
 }
+
+func TestSwizzleStr(t *testing.T) {
+	v := vec{1, 2, 3, 4}
+
+	cases := []struct {
+		mask     string
+		expected vec
+	}{
+		{"zyxx", vec{3, 2, 1, 1}},
+		{"w", vec{4}},
+		{"bgra", vec{3, 2, 1, 4}},
+		{"xyzwxyzw", vec{1, 2, 3, 4, 1, 2, 3, 4}},
+	}
+
+	for _, c := range cases {
+		if result, err := v.SwizzleStr(c.mask); err != nil || !result.Equal(c.expected) {
+			t.Errorf("%v: expected %v, got %v (%v)", c.mask, c.expected, result, err)
+		}
+
+		if result, err := vector.MutableVector(v).SwizzleStr(c.mask); err != nil || !vec(result).Equal(c.expected) {
+			t.Errorf("%v: expected %v from a mutable vector, got %v (%v)", c.mask, c.expected, result, err)
+		}
+	}
+
+	for _, mask := range []string{"", "xg", "xyq", "XY"} {
+		if _, err := v.SwizzleStr(mask); err != vector.ErrNotValidSwizzleIndex {
+			t.Errorf("expected the mask %q to be rejected, got %v", mask, err)
+		}
+	}
+
+	if _, err := (vec{1, 2}).SwizzleStr("xz"); err != vector.ErrNotValidSwizzleIndex {
+		t.Errorf("expected a component outside of the vector to be rejected, got %v", err)
+	}
+}
+
+func TestSwizzler(t *testing.T) {
+	s, err := vector.CompileSwizzle("zxy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, dst := vec{1, 2, 3}, make(vector.MutableVector, 3)
+	allocs := testing.AllocsPerRun(10, func() {
+		s.SwizzleTo(dst, v)
+	})
+
+	if allocs != 0 {
+		t.Errorf("expected no allocations when swizzling into a buffer, got %v", allocs)
+	}
+
+	if !vec(dst).Equal(vec{3, 1, 2}) {
+		t.Errorf("expected {3, 1, 2}, got %v", dst)
+	}
+
+	if err := s.SwizzleTo(make(vector.MutableVector, 2), v); err != vector.ErrNotSameDimensions {
+		t.Errorf("expected a short buffer to be rejected, got %v", err)
+	}
+
+	// swizzlers built from indices can name any component
+	long := vector.MutableVector{1, 2, 3, 4, 5, 6}
+	if err := (vector.Swizzler{5, 4}).SwizzleTo(dst, vec(long)); err != nil || !vec(dst[:2]).Equal(vec{6, 5}) {
+		t.Errorf("expected {6, 5}, got %v (%v)", dst[:2], err)
+	}
+
+	if err := (vector.Swizzler{4}).Set(long, vec{10}); err != nil || long[4] != 10 {
+		t.Errorf("expected the fifth component to be set, got %v (%v)", long, err)
+	}
+
+	for _, s := range []vector.Swizzler{{-1}, {6}, {0, -2}} {
+		if err := s.SwizzleTo(dst, vec(long)); err != vector.ErrNotValidSwizzleIndex {
+			t.Errorf("expected swizzling with %v to be rejected, got %v", s, err)
+		}
+
+		if err := s.Set(long, vec{0, 0}); err != vector.ErrNotValidSwizzleIndex {
+			t.Errorf("expected writing with %v to be rejected, got %v", s, err)
+		}
+	}
+
+	if err := (vector.Swizzler{5, 4, 5}).Set(long, vec{0, 0, 0}); err != vector.ErrNotValidSwizzleIndex {
+		t.Errorf("expected a repeated component to be rejected, got %v", err)
+	}
+}
+
+func TestSetSwizzle(t *testing.T) {
+	v := vector.MutableVector{1, 2, 3, 4}
+
+	if err := v.SetSwizzle("xz", vec{10, 30}); err != nil || !vec(v).Equal(vec{10, 2, 30, 4}) {
+		t.Errorf("expected {10, 2, 30, 4}, got %v (%v)", v, err)
+	}
+
+	if err := v.SetSwizzle("ar", vec{-1, -2, -3}); err != nil || !vec(v).Equal(vec{-2, 2, 30, -1}) {
+		t.Errorf("expected {-2, 2, 30, -1}, got %v (%v)", v, err)
+	}
+
+	for _, mask := range []string{"xx", "xw", "xr"} {
+		if err := v[:3].SetSwizzle(mask, vec{0, 0}); err != vector.ErrNotValidSwizzleIndex {
+			t.Errorf("expected writing to %q to be rejected, got %v", mask, err)
+		}
+	}
+
+	if err := v.SetSwizzle("xyz", vec{0, 0}); err != vector.ErrNotSameDimensions {
+		t.Errorf("expected a short vector to be rejected, got %v", err)
+	}
+
+	if !vec(v).Equal(vec{-2, 2, 30, -1}) {
+		t.Errorf("expected rejected writes to leave the vector untouched, got %v", v)
+	}
+}
+
+func TestElementwise(t *testing.T) {
+	a, b := vec{-1.5, 2.25, 4}, vec{2, -3}
+
+	cases := []struct {
+		name     string
+		result   vec
+		expected vec
+	}{
+		{"Mul", a.Mul(b), vec{-3, -6.75, 4}},
+		{"Div", a.Div(b), vec{-0.75, -0.75, 4}},
+		{"Min", a.Min(b), vec{-1.5, -3, 4}},
+		{"Max", a.Max(b), vec{2, 2.25, 4}},
+		{"Clamp", a.Clamp(vec{-1, -1, -1}, vec{1, 1}), vec{-1, 1, 4}},
+		{"Abs", a.Abs(), vec{1.5, 2.25, 4}},
+		{"Floor", a.Floor(), vec{-2, 2, 4}},
+		{"Ceil", a.Ceil(), vec{-1, 3, 4}},
+		{"Round", a.Round(), vec{-2, 2, 4}},
+		{"Sqrt", vec{4, 2.25}.Sqrt(), vec{2, 1.5}},
+		{"Pow", a.Pow(2), vec{2.25, 5.0625, 16}},
+		{"Map", a.Map(func(v float64) float64 { return v + 1 }), vec{-0.5, 3.25, 5}},
+		{"Apply", a.Apply(b, math.Atan2), vec{math.Atan2(-1.5, 2), math.Atan2(2.25, -3), 4}},
+	}
+
+	for _, c := range cases {
+		if !c.result.Equal(c.expected) {
+			t.Errorf("%v returned %v, expected %v", c.name, c.result, c.expected)
+		}
+	}
+
+	if !a.Equal(vec{-1.5, 2.25, 4}) {
+		t.Error("expected the operations on Vector to leave it untouched")
+	}
+
+	sum := a.Reduce(func(accumulator, v float64) float64 { return accumulator + v }, 10)
+	if sum != 14.75 {
+		t.Errorf("expected Reduce to sum to 14.75, got %v", sum)
+	}
+}
+
+func TestMutableElementwise(t *testing.T) {
+	a := vector.MutableVector{-1.5, 2.25, 4}
+
+	a.Mul(vec{2, 2}).Abs().Min(vec{10, 4}).Map(func(v float64) float64 { return v * 10 })
+	if !a.Equal(vec{30, 40, 40}) {
+		t.Errorf("expected the operations to be done in place, got %v", a)
+	}
+
+	a.Div(vec{3, 4, 5}).Pow(2).Sqrt().Clamp(vec{0, 0, 0}, vec{9, 9, 9})
+	if !a.Equal(vec{9, 9, 8}) {
+		t.Errorf("expected the operations to be done in place, got %v", a)
+	}
+
+	a.Apply(vec{0.5, -0.5}, func(a, b float64) float64 { return a + b }).Max(vec{9, 9, 9}).Floor()
+	if !a.Equal(vec{9, 9, 9}) || a.Reduce(math.Max, 0) != 9 {
+		t.Errorf("expected the operations to be done in place, got %v", a)
+	}
+
+	b := vector.MutableVector{0.5, 1.5, -0.4}
+	if !b.Clone().Round().Equal(vec{1, 2, 0}) || !b.Ceil().Equal(vec{1, 2, 0}) || !b.Equal(vec{1, 2, 0}) {
+		t.Errorf("unexpected rounding %v", b)
+	}
+}
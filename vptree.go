@@ -0,0 +1,210 @@
+package vector
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// VPTree is a vantage point tree, a spatial index that answers radius and
+// nearest neighbour queries for any metric that satisfies the triangle
+// inequality. The pruning of the tree gives wrong results for distances that
+// do not, like Cosine or the squared Euclidean distance, which need a linear
+// tree that compares the query with every vector instead. The tree refers to
+// the vectors by their index in the dataset it was built from.
+type VPTree struct {
+	data   []Vector
+	metric Metric
+	root   *vpNode
+	linear bool
+}
+
+type vpNode struct {
+	index     int
+	threshold float64
+	inside    *vpNode
+	outside   *vpNode
+}
+
+// NewVPTree builds a vantage point tree over a dataset. If metric is nil it
+// defaults to Euclidean. If linear is set no tree is built and every query
+// scans the whole dataset, which works for any distance.
+func NewVPTree(data []Vector, metric Metric, linear bool) *VPTree {
+	if metric == nil {
+		metric = Euclidean
+	}
+
+	indices := make([]int, len(data))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	t := &VPTree{data: data, metric: metric, linear: linear}
+	if linear {
+		return t
+	}
+
+	t.root = t.build(indices, make(Vector, len(data)))
+
+	return t
+}
+
+func (t *VPTree) build(indices []int, distances Vector) *vpNode {
+	if len(indices) == 0 {
+		return nil
+	}
+
+	// the middle index is used as vantage point to keep the tree deterministic
+	// while not picking the first vector of sorted datasets every time
+	mid := len(indices) / 2
+	indices[0], indices[mid] = indices[mid], indices[0]
+	node := &vpNode{index: indices[0]}
+	rest := indices[1:]
+
+	if len(rest) == 0 {
+		return node
+	}
+
+	vp := t.data[node.index]
+	for _, i := range rest {
+		distances[i] = t.metric(vp, t.data[i])
+	}
+
+	sort.Slice(rest, func(a, b int) bool {
+		return distances[rest[a]] < distances[rest[b]]
+	})
+
+	median := len(rest) / 2
+	node.threshold = distances[rest[median]]
+	node.inside = t.build(rest[:median], distances)
+	node.outside = t.build(rest[median:], distances)
+
+	return node
+}
+
+// Within returns the indices of all vectors within the given radius of the
+// query vector, including the radius itself.
+func (t *VPTree) Within(query Vector, radius float64) []int {
+	result := []int{}
+
+	if t.linear {
+		for i := range t.data {
+			if t.metric(query, t.data[i]) <= radius {
+				result = append(result, i)
+			}
+		}
+		return result
+	}
+
+	t.within(t.root, query, radius, &result)
+	return result
+}
+
+func (t *VPTree) within(node *vpNode, query Vector, radius float64, result *[]int) {
+	if node == nil {
+		return
+	}
+
+	d := t.metric(query, t.data[node.index])
+	if d <= radius {
+		*result = append(*result, node.index)
+	}
+
+	if d-radius <= node.threshold {
+		t.within(node.inside, query, radius, result)
+	}
+
+	if d+radius >= node.threshold {
+		t.within(node.outside, query, radius, result)
+	}
+}
+
+// Nearest returns the indices of the k nearest vectors to the query vector and
+// their distances, sorted by distance with the nearest first.
+func (t *VPTree) Nearest(query Vector, k int) ([]int, Vector) {
+	if k <= 0 {
+		return []int{}, Vector{}
+	}
+
+	h := &neighbours{}
+
+	if t.linear {
+		for i := range t.data {
+			h.offer(neighbour{i, t.metric(query, t.data[i])}, k)
+		}
+	} else {
+		t.nearest(t.root, query, k, h)
+	}
+
+	indices, distances := make([]int, h.Len()), make(Vector, h.Len())
+	for i := len(indices) - 1; i >= 0; i-- {
+		n := heap.Pop(h).(neighbour)
+		indices[i], distances[i] = n.index, n.distance
+	}
+
+	return indices, distances
+}
+
+func (t *VPTree) nearest(node *vpNode, query Vector, k int, h *neighbours) {
+	if node == nil {
+		return
+	}
+
+	d := t.metric(query, t.data[node.index])
+	h.offer(neighbour{node.index, d}, k)
+
+	tau := func() float64 {
+		if h.Len() < k {
+			return math.Inf(1)
+		}
+		return (*h)[0].distance
+	}
+
+	if d < node.threshold {
+		if d-tau() <= node.threshold {
+			t.nearest(node.inside, query, k, h)
+		}
+		if d+tau() >= node.threshold {
+			t.nearest(node.outside, query, k, h)
+		}
+		return
+	}
+
+	if d+tau() >= node.threshold {
+		t.nearest(node.outside, query, k, h)
+	}
+	if d-tau() <= node.threshold {
+		t.nearest(node.inside, query, k, h)
+	}
+}
+
+type neighbour struct {
+	index    int
+	distance float64
+}
+
+// neighbours is a max heap on the distance, so the furthest of the current k
+// nearest neighbours is at the top
+type neighbours []neighbour
+
+// offer adds a neighbour if there are fewer than k, or replaces the furthest
+// neighbour if it is nearer
+func (h *neighbours) offer(n neighbour, k int) {
+	if h.Len() < k {
+		heap.Push(h, n)
+	} else if n.distance < (*h)[0].distance {
+		(*h)[0] = n
+		heap.Fix(h, 0)
+	}
+}
+
+func (h neighbours) Len() int            { return len(h) }
+func (h neighbours) Less(i, j int) bool  { return h[i].distance > h[j].distance }
+func (h neighbours) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *neighbours) Push(x interface{}) { *h = append(*h, x.(neighbour)) }
+func (h *neighbours) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}